@@ -39,7 +39,6 @@ require (
 	github.com/cosmos/ibc-apps/modules/rate-limiting/v10 v10.0.0 // v10.1.0
 	github.com/cosmos/ibc-go/modules/light-clients/08-wasm/v10 v10.0.0 // v10.2.0
 	github.com/cosmos/ibc-go/v10 v10.1.0 // v10.2.0
-	github.com/fatih/color v1.18.0 // no update
 	github.com/golang/protobuf v1.5.4 // (deprecated)
 	github.com/gorilla/mux v1.8.1 // no update
 	github.com/grpc-ecosystem/grpc-gateway v1.16.0
@@ -314,6 +313,7 @@ require (
 	github.com/envoyproxy/go-control-plane/envoy v1.32.4 // indirect
 	github.com/envoyproxy/protoc-gen-validate v1.2.1 // indirect
 	github.com/ethereum/go-ethereum v1.15.5 // indirect
+	github.com/fatih/color v1.18.0 // indirect
 	github.com/go-jose/go-jose/v4 v4.1.1 // indirect
 	github.com/go-viper/mapstructure/v2 v2.4.0 // indirect
 	github.com/herumi/bls-eth-go-binary v0.0.0-20210917013441-d37c07cfda4e // indirect
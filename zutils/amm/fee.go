@@ -0,0 +1,163 @@
+package amm
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"zigchain/zutils/constants"
+)
+
+// Pool fees are expressed in units of 1/constants.PoolFeeScalingFactor,
+// e.g. with a scaling factor of 100_000 a fee of 500 is 0.5%.
+
+// MaxPoolFee is the highest fee accepted by ValidatePoolFee.
+// A fee of 100% or more would take the whole amount.
+const MaxPoolFee = uint32(constants.PoolFeeScalingFactor - 1)
+
+// ValidatePoolFee checks that the fee does not exceed MaxPoolFee.
+func ValidatePoolFee(fee uint32) error {
+	return ValidatePoolFeeWithMax(fee, MaxPoolFee)
+}
+
+// ValidatePoolFeeWithMax checks that the fee does not exceed maxFee.
+// maxFee itself cannot exceed MaxPoolFee.
+func ValidatePoolFeeWithMax(fee uint32, maxFee uint32) error {
+	if maxFee > MaxPoolFee {
+		return errorsmod.Wrapf(
+			sdkerrors.ErrInvalidRequest,
+			"invalid max pool fee: %d cannot be greater than %d",
+			maxFee,
+			MaxPoolFee,
+		)
+	}
+
+	if fee > maxFee {
+		return errorsmod.Wrapf(
+			sdkerrors.ErrInvalidRequest,
+			"invalid pool fee: %d cannot be greater than %d",
+			fee,
+			maxFee,
+		)
+	}
+
+	return nil
+}
+
+// ApplyFee splits an amount into the part that goes through the swap and the fee kept by the pool.
+//
+// The fee is rounded up, so any rounding is always in the pool's favour:
+// feeAmount = ceil(amount * fee / PoolFeeScalingFactor) and netAmount = amount - feeAmount.
+//
+// Parameters:
+//   - amount: the amount to apply the fee to, must be non-nil and non-negative.
+//   - fee: the pool fee, validated with ValidatePoolFee.
+//
+// Returns:
+//   - netAmount: the amount left after the fee.
+//   - feeAmount: the fee taken.
+//   - error: if the amount or the fee is invalid.
+func ApplyFee(amount sdkmath.Int, fee uint32) (netAmount sdkmath.Int, feeAmount sdkmath.Int, err error) {
+	return ApplyFeeWithMax(amount, fee, MaxPoolFee)
+}
+
+// ApplyFeeWithMax is ApplyFee with the fee validated against maxFee instead of MaxPoolFee,
+// for pools whose fee is capped by a module parameter.
+func ApplyFeeWithMax(amount sdkmath.Int, fee uint32, maxFee uint32) (netAmount sdkmath.Int, feeAmount sdkmath.Int, err error) {
+	if err := checkAmount("amount", amount); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	if err := ValidatePoolFeeWithMax(fee, maxFee); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	feeAmount, err = mulDivUp(amount, sdkmath.NewIntFromUint64(uint64(fee)), sdkmath.NewInt(constants.PoolFeeScalingFactor))
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	return amount.Sub(feeAmount), feeAmount, nil
+}
+
+// FeeToDec converts a pool fee to a decimal fraction, e.g. 500 -> 0.005.
+func FeeToDec(fee uint32) sdkmath.LegacyDec {
+	return sdkmath.LegacyNewDec(int64(fee)).QuoInt64(constants.PoolFeeScalingFactor)
+}
+
+// FeeFromDec converts a decimal fraction to a pool fee, e.g. 0.005 -> 500.
+// The fraction has to be exactly representable in fee units; it is never rounded.
+func FeeFromDec(dec sdkmath.LegacyDec) (uint32, error) {
+	if dec.IsNil() || dec.IsNegative() {
+		return 0, errorsmod.Wrapf(
+			sdkerrors.ErrInvalidRequest,
+			"invalid pool fee: %s has to be a non-negative decimal",
+			dec.String(),
+		)
+	}
+
+	// checked before scaling, as multiplying a large decimal overflows and panics
+	if dec.GT(FeeToDec(MaxPoolFee)) {
+		return 0, errorsmod.Wrapf(
+			sdkerrors.ErrInvalidRequest,
+			"invalid pool fee: %s cannot be greater than %s",
+			dec.String(),
+			FeeToDec(MaxPoolFee).String(),
+		)
+	}
+
+	scaled := dec.MulInt64(constants.PoolFeeScalingFactor)
+	if !scaled.IsInteger() {
+		return 0, errorsmod.Wrapf(
+			sdkerrors.ErrInvalidRequest,
+			"invalid pool fee: %s has more precision than 1/%d",
+			dec.String(),
+			constants.PoolFeeScalingFactor,
+		)
+	}
+
+	return uint32(scaled.TruncateInt64()), nil
+}
+
+// FeeToBasisPoints converts a pool fee to basis points (1 bp = 0.01%), e.g. 500 -> 50.
+// The fee is validated with ValidatePoolFee, and fees finer than a basis point cannot be converted.
+func FeeToBasisPoints(fee uint32) (uint32, error) {
+	return FeeToBasisPointsWithMax(fee, MaxPoolFee)
+}
+
+// FeeToBasisPointsWithMax is FeeToBasisPoints with the fee validated against maxFee instead of MaxPoolFee.
+func FeeToBasisPointsWithMax(fee uint32, maxFee uint32) (uint32, error) {
+	if err := ValidatePoolFeeWithMax(fee, maxFee); err != nil {
+		return 0, err
+	}
+
+	if fee%constants.PoolFeeBasisPoint != 0 {
+		return 0, errorsmod.Wrapf(
+			sdkerrors.ErrInvalidRequest,
+			"invalid pool fee: %d is not a whole number of basis points (multiple of %d)",
+			fee,
+			constants.PoolFeeBasisPoint,
+		)
+	}
+
+	return fee / constants.PoolFeeBasisPoint, nil
+}
+
+// FeeFromBasisPoints converts basis points to a pool fee, e.g. 50 -> 500, and validates it with ValidatePoolFee.
+func FeeFromBasisPoints(bps uint32) (uint32, error) {
+	if bps > MaxPoolFee/constants.PoolFeeBasisPoint {
+		return 0, errorsmod.Wrapf(
+			sdkerrors.ErrInvalidRequest,
+			"invalid pool fee: %d basis points cannot be greater than %d",
+			bps,
+			MaxPoolFee/constants.PoolFeeBasisPoint,
+		)
+	}
+
+	fee := bps * constants.PoolFeeBasisPoint
+	if err := ValidatePoolFee(fee); err != nil {
+		return 0, err
+	}
+
+	return fee, nil
+}
@@ -0,0 +1,188 @@
+package amm_test
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/amm"
+	"zigchain/zutils/constants"
+)
+
+func TestValidatePoolFee(t *testing.T) {
+	require.NoError(t, amm.ValidatePoolFee(0))
+	require.NoError(t, amm.ValidatePoolFee(500))
+	require.NoError(t, amm.ValidatePoolFee(amm.MaxPoolFee))
+
+	err := amm.ValidatePoolFee(constants.PoolFeeScalingFactor)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+	require.Equal(t, "invalid pool fee: 100000 cannot be greater than 99999: invalid request", err.Error())
+}
+
+func TestValidatePoolFeeWithMax(t *testing.T) {
+	require.NoError(t, amm.ValidatePoolFeeWithMax(1_000, 1_000))
+	require.Error(t, amm.ValidatePoolFeeWithMax(1_001, 1_000))
+
+	// the max itself is bounded
+	require.Error(t, amm.ValidatePoolFeeWithMax(0, constants.PoolFeeScalingFactor))
+}
+
+func TestApplyFee(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		fee    uint32
+		net    int64
+		feeAmt int64
+	}{
+		{name: "no fee", amount: 1_000, fee: 0, net: 1_000, feeAmt: 0},
+		{name: "exact", amount: 100_000, fee: 300, net: 99_700, feeAmt: 300},
+		{name: "fee rounded up", amount: 1_001, fee: 300, net: 997, feeAmt: 4},
+		{name: "tiny amount still pays fee", amount: 1, fee: 1, net: 0, feeAmt: 1},
+		{name: "zero amount", amount: 0, fee: 300, net: 0, feeAmt: 0},
+		{name: "max fee", amount: 100_000, fee: amm.MaxPoolFee, net: 1, feeAmt: 99_999},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			net, feeAmt, err := amm.ApplyFee(sdkmath.NewInt(tt.amount), tt.fee)
+			require.NoError(t, err)
+			require.Equal(t, tt.net, net.Int64())
+			require.Equal(t, tt.feeAmt, feeAmt.Int64())
+		})
+	}
+}
+
+func TestApplyFee_Invalid(t *testing.T) {
+	_, _, err := amm.ApplyFee(sdkmath.Int{}, 300)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+
+	_, _, err = amm.ApplyFee(sdkmath.NewInt(-1), 300)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+
+	_, _, err = amm.ApplyFee(sdkmath.NewInt(1), constants.PoolFeeScalingFactor)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+	maxInt, ok := sdkmath.NewIntFromString("115792089237316195423570985008687907853269984665640564039457584007913129639935")
+	require.True(t, ok)
+	_, _, err = amm.ApplyFee(maxInt, 2)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+}
+
+func TestApplyFeeWithMax(t *testing.T) {
+	net, feeAmt, err := amm.ApplyFeeWithMax(sdkmath.NewInt(100_000), 1_000, 1_000)
+	require.NoError(t, err)
+	require.Equal(t, int64(99_000), net.Int64())
+	require.Equal(t, int64(1_000), feeAmt.Int64())
+
+	// accepted by ApplyFee, but above the non-default max
+	_, _, err = amm.ApplyFeeWithMax(sdkmath.NewInt(100_000), 1_001, 1_000)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+	require.ErrorContains(t, err, "1001 cannot be greater than 1000")
+
+	_, _, err = amm.ApplyFeeWithMax(sdkmath.NewInt(100_000), 0, constants.PoolFeeScalingFactor)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+}
+
+func TestApplyFee_RoundingExhaustive(t *testing.T) {
+	// every fee value against amounts around the scaling factor boundaries
+	amounts := []int64{1, 2, 3, 7, 99, 999, 99_999, 100_000, 100_001, 123_456_789}
+	scale := sdkmath.NewInt(constants.PoolFeeScalingFactor)
+
+	for fee := uint32(0); fee <= amm.MaxPoolFee; fee++ {
+		for _, a := range amounts {
+			amount := sdkmath.NewInt(a)
+			net, feeAmt, err := amm.ApplyFee(amount, fee)
+			require.NoError(t, err)
+
+			// nothing is created or lost
+			require.True(t, net.Add(feeAmt).Equal(amount))
+			require.False(t, net.IsNegative())
+
+			// feeAmt is the smallest integer >= amount * fee / scale
+			exact := amount.MulRaw(int64(fee))
+			require.True(t, feeAmt.Mul(scale).GTE(exact), "fee %d amount %d", fee, a)
+			require.True(t, feeAmt.SubRaw(1).Mul(scale).LT(exact), "fee %d amount %d", fee, a)
+		}
+	}
+}
+
+func TestFeeDecConversion(t *testing.T) {
+	require.Equal(t, sdkmath.LegacyMustNewDecFromStr("0.005"), amm.FeeToDec(500))
+	require.Equal(t, sdkmath.LegacyZeroDec(), amm.FeeToDec(0))
+	require.Equal(t, sdkmath.LegacyMustNewDecFromStr("0.00001"), amm.FeeToDec(1))
+
+	fee, err := amm.FeeFromDec(sdkmath.LegacyMustNewDecFromStr("0.005"))
+	require.NoError(t, err)
+	require.Equal(t, uint32(500), fee)
+
+	// round trip over every valid fee
+	for f := uint32(0); f <= amm.MaxPoolFee; f++ {
+		back, err := amm.FeeFromDec(amm.FeeToDec(f))
+		require.NoError(t, err)
+		require.Equal(t, f, back)
+	}
+
+	_, err = amm.FeeFromDec(sdkmath.LegacyMustNewDecFromStr("0.000001"))
+	require.ErrorContains(t, err, "has more precision than 1/100000")
+
+	_, err = amm.FeeFromDec(sdkmath.LegacyMustNewDecFromStr("-0.01"))
+	require.ErrorContains(t, err, "has to be a non-negative decimal")
+
+	_, err = amm.FeeFromDec(sdkmath.LegacyDec{})
+	require.Error(t, err)
+
+	_, err = amm.FeeFromDec(sdkmath.LegacyOneDec())
+	require.ErrorContains(t, err, "cannot be greater than")
+
+	// a large decimal is rejected before scaling, which would overflow
+	large := sdkmath.LegacyNewDecFromIntWithPrec(sdkmath.NewIntWithDecimal(1, 73), 0)
+	require.NotPanics(t, func() {
+		_, err = amm.FeeFromDec(large)
+	})
+	require.ErrorContains(t, err, "cannot be greater than")
+}
+
+func TestFeeBasisPointsConversion(t *testing.T) {
+	bps, err := amm.FeeToBasisPoints(500)
+	require.NoError(t, err)
+	require.Equal(t, uint32(50), bps)
+
+	_, err = amm.FeeToBasisPoints(505)
+	require.ErrorContains(t, err, "is not a whole number of basis points")
+
+	_, err = amm.FeeToBasisPoints(constants.PoolFeeScalingFactor)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+	bps, err = amm.FeeToBasisPointsWithMax(1_000, 1_000)
+	require.NoError(t, err)
+	require.Equal(t, uint32(100), bps)
+
+	_, err = amm.FeeToBasisPointsWithMax(1_010, 1_000)
+	require.ErrorContains(t, err, "1010 cannot be greater than 1000")
+
+	fee, err := amm.FeeFromBasisPoints(30)
+	require.NoError(t, err)
+	require.Equal(t, uint32(300), fee)
+
+	fee, err = amm.FeeFromBasisPoints(9_999)
+	require.NoError(t, err)
+	require.Equal(t, uint32(99_990), fee)
+
+	_, err = amm.FeeFromBasisPoints(10_000)
+	require.Error(t, err)
+
+	// overflow of bps * 10 is rejected, not wrapped around
+	_, err = amm.FeeFromBasisPoints(^uint32(0))
+	require.Error(t, err)
+
+	for b := uint32(0); b < 10_000; b++ {
+		fee, err := amm.FeeFromBasisPoints(b)
+		require.NoError(t, err)
+		back, err := amm.FeeToBasisPoints(fee)
+		require.NoError(t, err)
+		require.Equal(t, b, back)
+	}
+}
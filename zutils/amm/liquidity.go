@@ -0,0 +1,152 @@
+package amm
+
+import (
+	"math/big"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MinimumLiquidity is the amount of pool shares locked forever when a pool is created.
+// It keeps the share supply from ever going back to zero, which would make the share price manipulable.
+const MinimumLiquidity = 1_000
+
+// InitialShares returns the pool shares minted for the first deposit into a pool.
+//
+// The total supply is floor(sqrt(amountA * amountB)), of which MinimumLiquidity is locked
+// and the rest goes to the depositor.
+//
+// Returns:
+//   - totalShares: the share supply of the new pool, locked shares included.
+//   - userShares: the shares minted to the depositor, totalShares - MinimumLiquidity.
+//   - error: if an amount is not positive or the deposit is too small to cover MinimumLiquidity.
+func InitialShares(amountA, amountB sdkmath.Int) (totalShares sdkmath.Int, userShares sdkmath.Int, err error) {
+	if err := checkPositive("amount", amountA); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	if err := checkPositive("amount", amountB); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	// the square root of the product of two 256-bit numbers always fits in 256 bits
+	totalShares = sdkmath.NewIntFromBigInt(new(big.Int).Sqrt(product(amountA, amountB)))
+	if totalShares.LTE(sdkmath.NewInt(MinimumLiquidity)) {
+		return sdkmath.Int{}, sdkmath.Int{}, errorsmod.Wrapf(
+			sdkerrors.ErrInsufficientFunds,
+			"initial liquidity %s has to be greater than the minimum liquidity %d",
+			totalShares,
+			MinimumLiquidity,
+		)
+	}
+
+	return totalShares, totalShares.SubRaw(MinimumLiquidity), nil
+}
+
+// AddLiquidity returns the pool shares minted for a deposit into an existing pool.
+//
+// Shares are minted in proportion to the pool reserves, limited by the scarcer of the two amounts:
+// shares = min(floor(amountA * totalShares / reserveA), floor(amountB * totalShares / reserveB)).
+// Only the amounts backing those shares are used, rounded up, the rest is left to be refunded.
+//
+// Returns:
+//   - shares: the shares minted to the depositor.
+//   - usedA, usedB: the amounts taken into the pool, never more than amountA and amountB.
+//   - error: if any input is invalid, the computation overflows or the deposit is too small to mint a share.
+func AddLiquidity(
+	amountA, amountB, reserveA, reserveB, totalShares sdkmath.Int,
+) (shares sdkmath.Int, usedA sdkmath.Int, usedB sdkmath.Int, err error) {
+	if err := checkPositive("amount", amountA); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	if err := checkPositive("amount", amountB); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	if err := checkReserves(reserveA, reserveB); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	if err := checkPositive("total shares", totalShares); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	sharesA, err := mulDiv(amountA, totalShares, reserveA)
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	sharesB, err := mulDiv(amountB, totalShares, reserveB)
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	shares = sdkmath.MinInt(sharesA, sharesB)
+	if !shares.IsPositive() {
+		return sdkmath.Int{}, sdkmath.Int{}, sdkmath.Int{}, errorsmod.Wrapf(
+			sdkerrors.ErrInvalidRequest,
+			"deposit of %s and %s is too small to mint any pool shares",
+			amountA,
+			amountB,
+		)
+	}
+
+	usedA, err = mulDivUp(shares, reserveA, totalShares)
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	usedB, err = mulDivUp(shares, reserveB, totalShares)
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	return shares, usedA, usedB, nil
+}
+
+// RemoveLiquidity returns the amounts paid out for burning pool shares.
+//
+// The amounts are proportional to the pool reserves and rounded down:
+// amountA = floor(shares * reserveA / totalShares), amountB = floor(shares * reserveB / totalShares).
+//
+// Returns:
+//   - amountA, amountB: the amounts paid out to the withdrawer.
+//   - error: if any input is invalid, shares exceeds totalShares or the computation overflows.
+func RemoveLiquidity(
+	shares, reserveA, reserveB, totalShares sdkmath.Int,
+) (amountA sdkmath.Int, amountB sdkmath.Int, err error) {
+	if err := checkPositive("shares", shares); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	if err := checkReserves(reserveA, reserveB); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	if err := checkPositive("total shares", totalShares); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	if shares.GT(totalShares) {
+		return sdkmath.Int{}, sdkmath.Int{}, errorsmod.Wrapf(
+			sdkerrors.ErrInsufficientFunds,
+			"shares %s cannot be greater than the total shares %s",
+			shares,
+			totalShares,
+		)
+	}
+
+	amountA, err = mulDiv(shares, reserveA, totalShares)
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	amountB, err = mulDiv(shares, reserveB, totalShares)
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	return amountA, amountB, nil
+}
@@ -0,0 +1,135 @@
+package amm_test
+
+import (
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/amm"
+)
+
+func TestInitialShares(t *testing.T) {
+	total, user, err := amm.InitialShares(sdkmath.NewInt(1_000_000), sdkmath.NewInt(4_000_000))
+	require.NoError(t, err)
+	require.Equal(t, "2000000", total.String())
+	require.Equal(t, "1999000", user.String())
+
+	// sqrt is rounded down
+	total, user, err = amm.InitialShares(sdkmath.NewInt(1_001), sdkmath.NewInt(1_002))
+	require.NoError(t, err)
+	require.Equal(t, "1001", total.String())
+	require.Equal(t, "1", user.String())
+
+	// no overflow with the largest amounts
+	total, _, err = amm.InitialShares(maxInt(t), maxInt(t))
+	require.NoError(t, err)
+	require.Equal(t, maxInt(t).String(), total.String())
+
+	_, _, err = amm.InitialShares(sdkmath.NewInt(1_000), sdkmath.NewInt(1_000))
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFunds)
+	require.Equal(t, "initial liquidity 1000 has to be greater than the minimum liquidity 1000: insufficient funds", err.Error())
+
+	_, _, err = amm.InitialShares(sdkmath.ZeroInt(), sdkmath.NewInt(1_000_000))
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+}
+
+func TestAddLiquidity(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		amountA  int64
+		amountB  int64
+		reserveA int64
+		shares   string
+		usedA    string
+		usedB    string
+	}{
+		{desc: "exact ratio", amountA: 1_000, amountB: 4_000, reserveA: 1_000_000, shares: "2000", usedA: "1000", usedB: "4000"},
+		{desc: "excess b is not used", amountA: 1_000, amountB: 5_000, reserveA: 1_000_000, shares: "2000", usedA: "1000", usedB: "4000"},
+		{desc: "used amounts rounded up", amountA: 1_000, amountB: 5_000, reserveA: 1_000_003, shares: "1999", usedA: "1000", usedB: "3998"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			shares, usedA, usedB, err := amm.AddLiquidity(
+				sdkmath.NewInt(tc.amountA),
+				sdkmath.NewInt(tc.amountB),
+				sdkmath.NewInt(tc.reserveA),
+				sdkmath.NewInt(4_000_000),
+				sdkmath.NewInt(2_000_000),
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.shares, shares.String())
+			require.Equal(t, tc.usedA, usedA.String())
+			require.Equal(t, tc.usedB, usedB.String())
+		})
+	}
+}
+
+func TestAddLiquidity_Invalid(t *testing.T) {
+	reserve := sdkmath.NewInt(1_000_000)
+
+	_, _, _, err := amm.AddLiquidity(sdkmath.NewInt(1), sdkmath.NewInt(1), reserve, reserve, sdkmath.NewInt(10))
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+	_, _, _, err = amm.AddLiquidity(sdkmath.NewInt(1), sdkmath.NewInt(1), reserve, reserve, sdkmath.ZeroInt())
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+
+	_, _, _, err = amm.AddLiquidity(maxInt(t), maxInt(t), reserve, reserve, reserve)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+}
+
+func TestRemoveLiquidity(t *testing.T) {
+	amountA, amountB, err := amm.RemoveLiquidity(
+		sdkmath.NewInt(2_000), sdkmath.NewInt(1_000_000), sdkmath.NewInt(4_000_000), sdkmath.NewInt(2_000_000),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "1000", amountA.String())
+	require.Equal(t, "4000", amountB.String())
+
+	// amounts are rounded down
+	amountA, amountB, err = amm.RemoveLiquidity(
+		sdkmath.NewInt(1), sdkmath.NewInt(10), sdkmath.NewInt(5), sdkmath.NewInt(3),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "3", amountA.String())
+	require.Equal(t, "1", amountB.String())
+
+	_, _, err = amm.RemoveLiquidity(
+		sdkmath.NewInt(4), sdkmath.NewInt(10), sdkmath.NewInt(5), sdkmath.NewInt(3),
+	)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFunds)
+}
+
+func FuzzAddRemoveLiquidity(f *testing.F) {
+	f.Add(uint64(1_000), uint64(5_000), uint64(1_000_003), uint64(4_000_000), uint64(2_000_000))
+	f.Add(uint64(1), uint64(1), uint64(1), uint64(1), uint64(1))
+	f.Add(^uint64(0), ^uint64(0), uint64(3), uint64(7), uint64(5))
+
+	f.Fuzz(func(t *testing.T, a uint64, b uint64, reserveA uint64, reserveB uint64, total uint64) {
+		amountA := sdkmath.NewIntFromUint64(a)
+		amountB := sdkmath.NewIntFromUint64(b)
+		rA := sdkmath.NewIntFromUint64(reserveA)
+		rB := sdkmath.NewIntFromUint64(reserveB)
+		totalShares := sdkmath.NewIntFromUint64(total)
+
+		shares, usedA, usedB, err := amm.AddLiquidity(amountA, amountB, rA, rB, totalShares)
+		if err != nil {
+			return
+		}
+
+		require.True(t, usedA.LTE(amountA))
+		require.True(t, usedB.LTE(amountB))
+
+		// withdrawing right away never returns more than was deposited
+		newA, newB, newTotal := rA.Add(usedA), rB.Add(usedB), totalShares.Add(shares)
+		outA, outB, err := amm.RemoveLiquidity(shares, newA, newB, newTotal)
+		require.NoError(t, err)
+		require.True(t, outA.LTE(usedA), "got %s, deposited %s", outA, usedA)
+		require.True(t, outB.LTE(usedB), "got %s, deposited %s", outB, usedB)
+
+		// the share supply is back to where it was, so x*y cannot have decreased
+		requireProductNotDecreased(t, rA, rB, newA.Sub(outA), newB.Sub(outB))
+	})
+}
@@ -0,0 +1,78 @@
+package amm
+
+import (
+	"math/big"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// checkAmount ensures the amount is non-nil and non-negative.
+func checkAmount(name string, amount sdkmath.Int) error {
+	if amount.IsNil() {
+		return errorsmod.Wrapf(
+			sdkerrors.ErrInvalidCoins,
+			"invalid %s: cannot be nil",
+			name,
+		)
+	}
+
+	if amount.IsNegative() {
+		return errorsmod.Wrapf(
+			sdkerrors.ErrInvalidCoins,
+			"invalid %s: %s cannot be negative",
+			name,
+			amount.String(),
+		)
+	}
+
+	return nil
+}
+
+// checkPositive ensures the amount is non-nil and greater than zero.
+func checkPositive(name string, amount sdkmath.Int) error {
+	if err := checkAmount(name, amount); err != nil {
+		return err
+	}
+
+	if amount.IsZero() {
+		return errorsmod.Wrapf(
+			sdkerrors.ErrInvalidCoins,
+			"invalid %s: has to be positive",
+			name,
+		)
+	}
+
+	return nil
+}
+
+// mulDiv returns floor(a * b / c) and reports an overflow of the intermediate product.
+func mulDiv(a, b, c sdkmath.Int) (sdkmath.Int, error) {
+	product, err := a.SafeMul(b)
+	if err != nil {
+		return sdkmath.Int{}, errorsmod.Wrapf(sdkerrors.ErrInvalidCoins, "amount overflow: %s * %s (%s)", a, b, err)
+	}
+
+	return product.Quo(c), nil
+}
+
+// mulDivUp returns ceil(a * b / c) and reports an overflow of the intermediate product.
+func mulDivUp(a, b, c sdkmath.Int) (sdkmath.Int, error) {
+	product, err := a.SafeMul(b)
+	if err != nil {
+		return sdkmath.Int{}, errorsmod.Wrapf(sdkerrors.ErrInvalidCoins, "amount overflow: %s * %s (%s)", a, b, err)
+	}
+
+	quo := product.Quo(c)
+	if !product.Mod(c).IsZero() {
+		quo = quo.AddRaw(1)
+	}
+
+	return quo, nil
+}
+
+// product returns a * b as a big.Int, it never overflows.
+func product(a, b sdkmath.Int) *big.Int {
+	return new(big.Int).Mul(a.BigInt(), b.BigInt())
+}
@@ -0,0 +1,187 @@
+package amm
+
+import (
+	"math/big"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"zigchain/zutils/constants"
+)
+
+// Swaps follow the constant-product formula x * y = k, with the pool fee taken from the input.
+// All rounding is done in the pool's favour so that k never decreases.
+
+// SwapOut returns the amount received for swapping amountIn into the pool.
+//
+// The fee is taken from amountIn with ApplyFee and the remaining amount is swapped:
+// amountOut = floor(netIn * reserveOut / (reserveIn + netIn)).
+//
+// Parameters:
+//   - amountIn: the amount sent to the pool, must be positive.
+//   - reserveIn: the pool reserve of the input denom, must be positive.
+//   - reserveOut: the pool reserve of the output denom, must be positive.
+//   - fee: the pool fee, validated with ValidatePoolFee.
+//
+// Returns:
+//   - amountOut: the amount sent back to the trader, always positive.
+//   - feeAmount: the part of amountIn kept as fee.
+//   - error: if any input is invalid, the computation overflows or amountIn is too small to get any output.
+func SwapOut(amountIn, reserveIn, reserveOut sdkmath.Int, fee uint32) (amountOut sdkmath.Int, feeAmount sdkmath.Int, err error) {
+	if err := checkPositive("amount in", amountIn); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	if err := checkReserves(reserveIn, reserveOut); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	netIn, feeAmount, err := ApplyFee(amountIn, fee)
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	newReserveIn, err := reserveIn.SafeAdd(netIn)
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, errorsmod.Wrapf(sdkerrors.ErrInvalidCoins, "amount overflow: %s + %s (%s)", reserveIn, netIn, err)
+	}
+
+	amountOut, err = mulDiv(netIn, reserveOut, newReserveIn)
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	if !amountOut.IsPositive() {
+		return sdkmath.Int{}, sdkmath.Int{}, errorsmod.Wrapf(
+			sdkerrors.ErrInvalidRequest,
+			"amount in %s is too small, the swap output would be zero",
+			amountIn,
+		)
+	}
+
+	if err := CheckInvariant(reserveIn, reserveOut, newReserveIn, reserveOut.Sub(amountOut)); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	return amountOut, feeAmount, nil
+}
+
+// SwapIn returns the amount that has to be sent to the pool to receive amountOut.
+//
+// It is the inverse of SwapOut: swapping the returned amountIn with SwapOut yields at least amountOut.
+//
+// Parameters:
+//   - amountOut: the amount the trader wants to receive, must be positive and lower than reserveOut.
+//   - reserveIn: the pool reserve of the input denom, must be positive.
+//   - reserveOut: the pool reserve of the output denom, must be positive.
+//   - fee: the pool fee, validated with ValidatePoolFee.
+//
+// Returns:
+//   - amountIn: the amount to send to the pool, fee included.
+//   - feeAmount: the part of amountIn kept as fee.
+//   - error: if any input is invalid or the computation overflows.
+func SwapIn(amountOut, reserveIn, reserveOut sdkmath.Int, fee uint32) (amountIn sdkmath.Int, feeAmount sdkmath.Int, err error) {
+	if err := checkPositive("amount out", amountOut); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	if err := checkReserves(reserveIn, reserveOut); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	if err := ValidatePoolFee(fee); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	if amountOut.GTE(reserveOut) {
+		return sdkmath.Int{}, sdkmath.Int{}, errorsmod.Wrapf(
+			sdkerrors.ErrInsufficientFunds,
+			"amount out %s has to be lower than the reserve %s",
+			amountOut,
+			reserveOut,
+		)
+	}
+
+	// netIn = ceil(reserveIn * amountOut / (reserveOut - amountOut))
+	netIn, err := mulDivUp(reserveIn, amountOut, reserveOut.Sub(amountOut))
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	// amountIn = ceil(netIn * scale / (scale - fee)), so that ApplyFee(amountIn) leaves at least netIn
+	amountIn, err = mulDivUp(netIn, sdkmath.NewInt(constants.PoolFeeScalingFactor), sdkmath.NewInt(constants.PoolFeeScalingFactor-int64(fee)))
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	net, feeAmount, err := ApplyFee(amountIn, fee)
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	newReserveIn, err := reserveIn.SafeAdd(net)
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, errorsmod.Wrapf(sdkerrors.ErrInvalidCoins, "amount overflow: %s + %s (%s)", reserveIn, net, err)
+	}
+
+	if err := CheckInvariant(reserveIn, reserveOut, newReserveIn, reserveOut.Sub(amountOut)); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	return amountIn, feeAmount, nil
+}
+
+// PriceImpact returns how much worse than the spot price a swap of amountIn executes, as a fraction in [0, 1).
+//
+// The fee is not part of the price impact, for a fee-less swap it is amountIn / (reserveIn + amountIn).
+// The result is truncated to the precision of sdkmath.LegacyDec.
+func PriceImpact(amountIn, reserveIn, reserveOut sdkmath.Int) (sdkmath.LegacyDec, error) {
+	if err := checkPositive("amount in", amountIn); err != nil {
+		return sdkmath.LegacyDec{}, err
+	}
+
+	if err := checkReserves(reserveIn, reserveOut); err != nil {
+		return sdkmath.LegacyDec{}, err
+	}
+
+	precision := new(big.Int).Exp(big.NewInt(10), big.NewInt(sdkmath.LegacyPrecision), nil)
+	impact := new(big.Int).Mul(amountIn.BigInt(), precision)
+	impact.Quo(impact, new(big.Int).Add(reserveIn.BigInt(), amountIn.BigInt()))
+
+	return sdkmath.LegacyNewDecFromBigIntWithPrec(impact, sdkmath.LegacyPrecision), nil
+}
+
+// CheckInvariant checks that the constant product did not decrease:
+// newReserveA * newReserveB >= reserveA * reserveB.
+// The products are computed without overflow.
+func CheckInvariant(reserveA, reserveB, newReserveA, newReserveB sdkmath.Int) error {
+	for _, reserve := range []sdkmath.Int{reserveA, reserveB, newReserveA, newReserveB} {
+		if err := checkAmount("reserve", reserve); err != nil {
+			return err
+		}
+	}
+
+	before := product(reserveA, reserveB)
+	after := product(newReserveA, newReserveB)
+	if after.Cmp(before) < 0 {
+		return errorsmod.Wrapf(
+			sdkerrors.ErrLogic,
+			"constant product decreased: %s * %s < %s * %s",
+			newReserveA,
+			newReserveB,
+			reserveA,
+			reserveB,
+		)
+	}
+
+	return nil
+}
+
+// checkReserves ensures both pool reserves are positive.
+func checkReserves(reserveA, reserveB sdkmath.Int) error {
+	if err := checkPositive("reserve", reserveA); err != nil {
+		return err
+	}
+	return checkPositive("reserve", reserveB)
+}
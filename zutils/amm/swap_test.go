@@ -0,0 +1,162 @@
+package amm_test
+
+import (
+	"math/big"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/amm"
+)
+
+func TestSwapOut(t *testing.T) {
+	amountOut, feeAmount, err := amm.SwapOut(sdkmath.NewInt(10_000), sdkmath.NewInt(1_000_000), sdkmath.NewInt(1_000_000), 300)
+	require.NoError(t, err)
+	require.Equal(t, "9871", amountOut.String())
+	require.Equal(t, "30", feeAmount.String())
+
+	// without a fee only the curve reduces the output
+	amountOut, feeAmount, err = amm.SwapOut(sdkmath.NewInt(1_000), sdkmath.NewInt(1_000), sdkmath.NewInt(1_000), 0)
+	require.NoError(t, err)
+	require.Equal(t, "500", amountOut.String())
+	require.True(t, feeAmount.IsZero())
+}
+
+func TestSwapOut_Invalid(t *testing.T) {
+	one := sdkmath.NewInt(1)
+	reserve := sdkmath.NewInt(1_000_000)
+
+	testCases := []struct {
+		desc       string
+		amountIn   sdkmath.Int
+		reserveIn  sdkmath.Int
+		reserveOut sdkmath.Int
+		fee        uint32
+		err        error
+	}{
+		{desc: "nil amount", amountIn: sdkmath.Int{}, reserveIn: reserve, reserveOut: reserve, err: sdkerrors.ErrInvalidCoins},
+		{desc: "zero amount", amountIn: sdkmath.ZeroInt(), reserveIn: reserve, reserveOut: reserve, err: sdkerrors.ErrInvalidCoins},
+		{desc: "empty reserve in", amountIn: one, reserveIn: sdkmath.ZeroInt(), reserveOut: reserve, err: sdkerrors.ErrInvalidCoins},
+		{desc: "negative reserve out", amountIn: one, reserveIn: reserve, reserveOut: sdkmath.NewInt(-1), err: sdkerrors.ErrInvalidCoins},
+		{desc: "invalid fee", amountIn: one, reserveIn: reserve, reserveOut: reserve, fee: amm.MaxPoolFee + 1, err: sdkerrors.ErrInvalidRequest},
+		{desc: "zero output", amountIn: one, reserveIn: reserve, reserveOut: one, err: sdkerrors.ErrInvalidRequest},
+		{desc: "overflow", amountIn: maxInt(t), reserveIn: reserve, reserveOut: maxInt(t), err: sdkerrors.ErrInvalidCoins},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, err := amm.SwapOut(tc.amountIn, tc.reserveIn, tc.reserveOut, tc.fee)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestSwapIn(t *testing.T) {
+	amountIn, feeAmount, err := amm.SwapIn(sdkmath.NewInt(9_871), sdkmath.NewInt(1_000_000), sdkmath.NewInt(1_000_000), 300)
+	require.NoError(t, err)
+	require.Equal(t, "10000", amountIn.String())
+	require.Equal(t, "30", feeAmount.String())
+
+	_, _, err = amm.SwapIn(sdkmath.NewInt(1_000_000), sdkmath.NewInt(1_000_000), sdkmath.NewInt(1_000_000), 300)
+	require.ErrorIs(t, err, sdkerrors.ErrInsufficientFunds)
+
+	_, _, err = amm.SwapIn(sdkmath.ZeroInt(), sdkmath.NewInt(1_000_000), sdkmath.NewInt(1_000_000), 300)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+}
+
+func TestPriceImpact(t *testing.T) {
+	impact, err := amm.PriceImpact(sdkmath.NewInt(10_000), sdkmath.NewInt(1_000_000), sdkmath.NewInt(1_000_000))
+	require.NoError(t, err)
+	require.Equal(t, "0.009900990099009900", impact.String())
+
+	// half the reserve in moves the price by a third
+	impact, err = amm.PriceImpact(sdkmath.NewInt(500), sdkmath.NewInt(1_000), sdkmath.NewInt(7))
+	require.NoError(t, err)
+	require.Equal(t, "0.333333333333333333", impact.String())
+
+	// huge amounts do not overflow
+	impact, err = amm.PriceImpact(maxInt(t), maxInt(t), maxInt(t))
+	require.NoError(t, err)
+	require.Equal(t, "0.500000000000000000", impact.String())
+
+	_, err = amm.PriceImpact(sdkmath.NewInt(1), sdkmath.ZeroInt(), sdkmath.NewInt(1))
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+}
+
+func TestCheckInvariant(t *testing.T) {
+	require.NoError(t, amm.CheckInvariant(sdkmath.NewInt(10), sdkmath.NewInt(10), sdkmath.NewInt(20), sdkmath.NewInt(5)))
+	require.NoError(t, amm.CheckInvariant(maxInt(t), maxInt(t), maxInt(t), maxInt(t)))
+
+	err := amm.CheckInvariant(sdkmath.NewInt(10), sdkmath.NewInt(10), sdkmath.NewInt(20), sdkmath.NewInt(4))
+	require.ErrorIs(t, err, sdkerrors.ErrLogic)
+	require.Equal(t, "constant product decreased: 20 * 4 < 10 * 10: internal logic error", err.Error())
+}
+
+func FuzzSwapOut(f *testing.F) {
+	f.Add(uint64(10_000), uint64(1_000_000), uint64(1_000_000), uint32(300))
+	f.Add(uint64(1), uint64(1), uint64(1), uint32(0))
+	f.Add(^uint64(0), uint64(3), ^uint64(0), amm.MaxPoolFee)
+
+	f.Fuzz(func(t *testing.T, in uint64, reserveIn uint64, reserveOut uint64, fee uint32) {
+		fee %= amm.MaxPoolFee + 1
+		amountIn := sdkmath.NewIntFromUint64(in)
+		rIn := sdkmath.NewIntFromUint64(reserveIn)
+		rOut := sdkmath.NewIntFromUint64(reserveOut)
+
+		amountOut, feeAmount, err := amm.SwapOut(amountIn, rIn, rOut, fee)
+		if err != nil {
+			return
+		}
+
+		require.True(t, amountOut.IsPositive())
+		require.True(t, amountOut.LT(rOut))
+
+		// x * y never decreases, even before the fee is added to the reserves
+		netIn := amountIn.Sub(feeAmount)
+		requireProductNotDecreased(t, rIn, rOut, rIn.Add(netIn), rOut.Sub(amountOut))
+	})
+}
+
+func FuzzSwapIn(f *testing.F) {
+	f.Add(uint64(9_871), uint64(1_000_000), uint64(1_000_000), uint32(300))
+	f.Add(uint64(1), uint64(1), uint64(2), uint32(0))
+	f.Add(^uint64(0)-1, ^uint64(0), ^uint64(0), amm.MaxPoolFee)
+
+	f.Fuzz(func(t *testing.T, out uint64, reserveIn uint64, reserveOut uint64, fee uint32) {
+		fee %= amm.MaxPoolFee + 1
+		amountOut := sdkmath.NewIntFromUint64(out)
+		rIn := sdkmath.NewIntFromUint64(reserveIn)
+		rOut := sdkmath.NewIntFromUint64(reserveOut)
+
+		amountIn, feeAmount, err := amm.SwapIn(amountOut, rIn, rOut, fee)
+		if err != nil {
+			return
+		}
+
+		netIn := amountIn.Sub(feeAmount)
+		requireProductNotDecreased(t, rIn, rOut, rIn.Add(netIn), rOut.Sub(amountOut))
+
+		// swapping the quoted input gives at least the requested output
+		got, _, err := amm.SwapOut(amountIn, rIn, rOut, fee)
+		require.NoError(t, err)
+		require.True(t, got.GTE(amountOut), "got %s, want at least %s", got, amountOut)
+	})
+}
+
+func requireProductNotDecreased(t *testing.T, x, y, newX, newY sdkmath.Int) {
+	t.Helper()
+
+	before := new(big.Int).Mul(x.BigInt(), y.BigInt())
+	after := new(big.Int).Mul(newX.BigInt(), newY.BigInt())
+	require.True(t, after.Cmp(before) >= 0, "x*y decreased from %s to %s", before, after)
+}
+
+func maxInt(t *testing.T) sdkmath.Int {
+	t.Helper()
+
+	v, ok := sdkmath.NewIntFromString("115792089237316195423570985008687907853269984665640564039457584007913129639935")
+	require.True(t, ok)
+	return v
+}
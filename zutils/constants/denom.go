@@ -4,15 +4,33 @@ package constants
 const (
 	BondDenom = "uzig"
 
+	// BondDisplayDenom display denomination of the native coin (1 zig = 10^BondDenomDecimals uzig)
+	BondDisplayDenom = "zig"
+
 	// BondDenomDecimals number of decimal places in the native coin
 	BondDenomDecimals = 6
 
+	// MaxDecimalDifference maximum difference in decimal places between two denoms that can be converted
+	MaxDecimalDifference = 18
+
 	// MinSubDenomLength minimum length of the coin denomination
 	MinSubDenomLength = 3
 
 	// MaxSubDenomLength maximum length of the coin denomination
 	MaxSubDenomLength = 44
 
+	// FactoryDenomPrefix first component of a factory denom: factory/{creator}/{subdenom}
+	FactoryDenomPrefix = "factory"
+
+	// IBCDenomPrefix first component of an IBC voucher denom: ibc/{hash}
+	IBCDenomPrefix = "ibc"
+
+	// CW20DenomPrefix prefix of a CosmWasm CW20 token denom: cw20:{contract}
+	CW20DenomPrefix = "cw20:"
+
+	// DenomSeparator separates the components of a factory denom
+	DenomSeparator = "/"
+
 	// MaxDenomLength len("factory") = 7 + 1 + macCreatorLength + 1 + MaxSubdenomLength = 8 + 1 + 59 + 1 + 44 = 112
 	// We leave some buffer - 128 is cosmos limitation anyway
 	MaxDenomLength = 127
@@ -24,4 +42,10 @@ const (
 	// This string is usually represented in the hexadecimal format (as 64 characters [0-9a-f]),
 	// but it's not a requirement. One may choose to use a different encoding to make the produced string shorter.
 	MaxURIHashLength = 64
+
+	// MaxMetadataNameLength maximum length of the name in the metadata of a denom
+	MaxMetadataNameLength = 64
+
+	// MaxMetadataSymbolLength maximum length of the symbol in the metadata of a denom, e.g. ZIG
+	MaxMetadataSymbolLength = 16
 )
@@ -5,3 +5,12 @@ const (
 	BlockChainName = "zigchain"
 	CoinType       = 118
 )
+
+// Bech32 prefixes of the other address roles, derived from AddressPrefix the same way the SDK does.
+const (
+	AccountPubKeyPrefix    = AddressPrefix + "pub"
+	ValidatorAddressPrefix = AddressPrefix + "valoper"
+	ValidatorPubKeyPrefix  = AddressPrefix + "valoperpub"
+	ConsensusAddressPrefix = AddressPrefix + "valcons"
+	ConsensusPubKeyPrefix  = AddressPrefix + "valconspub"
+)
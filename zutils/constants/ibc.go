@@ -5,3 +5,9 @@ const (
 	// MaxIBCCallbackGas should roughly be a couple orders of magnitude larger than needed.
 	MaxIBCCallbackGas = uint64(10_000_000)
 )
+
+// Memo constants
+const (
+	// MaxForwardHops is the maximum number of nested forwards in a packet-forward-middleware memo.
+	MaxForwardHops = 8
+)
@@ -0,0 +1,34 @@
+package constants
+
+// Module account names, used to derive the module account addresses.
+const (
+	// FeeCollectorName module account collecting the transaction fees
+	FeeCollectorName = "fee_collector"
+
+	// DistributionModuleName module account holding the staking rewards and the community pool
+	DistributionModuleName = "distribution"
+
+	// BondedPoolName module account holding the bonded stake
+	BondedPoolName = "bonded_tokens_pool"
+
+	// NotBondedPoolName module account holding the unbonding stake
+	NotBondedPoolName = "not_bonded_tokens_pool"
+
+	// GovModuleName module account holding the proposal deposits, also the default authority
+	GovModuleName = "gov"
+
+	// MintModuleName module account minting the inflation
+	MintModuleName = "mint"
+
+	// TransferModuleName module account of the ICS-20 transfer module
+	TransferModuleName = "transfer"
+
+	// DexModuleName module account of the dex, pool accounts are derived from it
+	DexModuleName = "dex"
+
+	// FactoryModuleName module account of the token factory
+	FactoryModuleName = "factory"
+
+	// TokenWrapperModuleName module account holding the tokens locked by the token wrapper
+	TokenWrapperModuleName = "tokenwrapper"
+)
@@ -3,5 +3,6 @@ package constants
 const (
 	PoolPrefix           = "zp"           // pool prefix
 	PoolFeeScalingFactor = 100_000        // fee scaling factor 100K
+	PoolFeeBasisPoint    = 10             // one basis point (0.01%) in fee units
 	MaxPoolID            = 99_999_999_999 // max pool id
 )
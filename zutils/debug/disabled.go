@@ -0,0 +1,7 @@
+//go:build !zdebug
+
+package d
+
+// Enabled is false in builds without the zdebug tag: the functions of this package are no-ops
+// and the compiler removes their bodies, so release builds pay nothing for leftover debug calls.
+const Enabled = false
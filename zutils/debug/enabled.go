@@ -0,0 +1,6 @@
+//go:build zdebug
+
+package d
+
+// Enabled is true in builds with the zdebug tag: the functions of this package log.
+const Enabled = true
@@ -0,0 +1,173 @@
+// Package d is a debug logging facility for development builds.
+//
+// Build with the zdebug tag to enable it, e.g. go build -tags zdebug ./...
+// Without the tag every function is a no-op. The output is configured with environment variables:
+//
+//	ZDEBUG_LOG_LEVEL   cosmos-sdk log level, e.g. "debug" (default), "info" or "dex:debug,*:error"; "disabled" turns it off
+//	ZDEBUG_LOG_FORMAT  "plain" (default) or "json" for log aggregation
+//
+// Entries are written to stderr through cosmossdk.io/log, unless SetLogger routes them elsewhere,
+// e.g. to the app logger. Every entry carries the caller file and line under CallerKey.
+package d
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"cosmossdk.io/log"
+)
+
+// Environment variables configuring the default logger.
+const (
+	EnvLogLevel  = "ZDEBUG_LOG_LEVEL"
+	EnvLogFormat = "ZDEBUG_LOG_FORMAT"
+)
+
+// Log formats accepted in EnvLogFormat, the values of the cosmos-sdk --log_format flag.
+const (
+	FormatPlain = "plain"
+	FormatJSON  = "json"
+)
+
+// CallerKey is the key of the caller file and line in every entry.
+const CallerKey = "caller"
+
+// DefaultLevel is the log level used when EnvLogLevel is not set.
+const DefaultLevel = "debug"
+
+// Config configures a debug logger.
+type Config struct {
+	// Level is a cosmos-sdk log level, see log.ParseLogLevel.
+	Level string
+	// Format is FormatPlain or FormatJSON.
+	Format string
+}
+
+// ConfigFromEnv reads the configuration from EnvLogLevel and EnvLogFormat, with the defaults for unset variables.
+func ConfigFromEnv() Config {
+	cfg := Config{Level: os.Getenv(EnvLogLevel), Format: os.Getenv(EnvLogFormat)}
+	if cfg.Level == "" {
+		cfg.Level = DefaultLevel
+	}
+	if cfg.Format == "" {
+		cfg.Format = FormatPlain
+	}
+	return cfg
+}
+
+// NewLogger creates a logger writing to w with the given configuration.
+// The plain format has no colors, so the output stays readable in node logs.
+func NewLogger(w io.Writer, cfg Config) (log.Logger, error) {
+	filter, err := log.ParseLogLevel(cfg.Level)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", EnvLogLevel, err)
+	}
+
+	opts := []log.Option{log.FilterOption(filter), log.ColorOption(false)}
+	switch strings.ToLower(cfg.Format) {
+	case FormatPlain:
+	case FormatJSON:
+		opts = append(opts, log.OutputJSONOption())
+	default:
+		return nil, fmt.Errorf("invalid %s: %q, expected %s or %s", EnvLogFormat, cfg.Format, FormatPlain, FormatJSON)
+	}
+
+	return log.NewLogger(w, opts...), nil
+}
+
+var (
+	mu     sync.RWMutex
+	logger log.Logger
+)
+
+// Logger returns the debug logger: the one set with SetLogger, or a logger writing to stderr configured
+// from the environment. It returns a no-op logger without the zdebug tag.
+func Logger() log.Logger {
+	if !Enabled {
+		return log.NewNopLogger()
+	}
+
+	mu.RLock()
+	l := logger
+	mu.RUnlock()
+	if l != nil {
+		return l
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if logger == nil {
+		var err error
+		logger, err = NewLogger(os.Stderr, ConfigFromEnv())
+		if err != nil {
+			// a typo in the environment should not hide the debug output
+			logger, _ = NewLogger(os.Stderr, Config{Level: DefaultLevel, Format: FormatPlain})
+			logger.Error("falling back to the default debug logger", "err", err)
+		}
+	}
+	return logger
+}
+
+// SetLogger routes the debug entries to l, e.g. app.Logger(). A nil logger restores the default one.
+func SetLogger(l log.Logger) {
+	mu.Lock()
+	logger = l
+	mu.Unlock()
+}
+
+// Module returns the debug logger with the module key set, so entries can be filtered per module.
+func Module(name string) log.Logger {
+	return Logger().With(log.ModuleKey, name)
+}
+
+// Debug logs a message with key/value pairs at debug level.
+func Debug(msg string, keyVals ...interface{}) {
+	if !Enabled {
+		return
+	}
+	Logger().Debug(msg, withCaller(2, keyVals)...)
+}
+
+// Info logs a message with key/value pairs at info level.
+func Info(msg string, keyVals ...interface{}) {
+	if !Enabled {
+		return
+	}
+	Logger().Info(msg, withCaller(2, keyVals)...)
+}
+
+// Warn logs a message with key/value pairs at warn level.
+func Warn(msg string, keyVals ...interface{}) {
+	if !Enabled {
+		return
+	}
+	Logger().Warn(msg, withCaller(2, keyVals)...)
+}
+
+// Error logs a message with key/value pairs at error level.
+func Error(msg string, keyVals ...interface{}) {
+	if !Enabled {
+		return
+	}
+	Logger().Error(msg, withCaller(2, keyVals)...)
+}
+
+// withCaller appends the CallerKey pair of the function skip frames up the stack.
+func withCaller(skip int, keyVals []interface{}) []interface{} {
+	// the full slice expression makes append copy, the slice of the caller is never written
+	return append(keyVals[:len(keyVals):len(keyVals)], CallerKey, caller(skip+1))
+}
+
+// caller returns "dir/file.go:line" of the function skip frames up the stack.
+func caller(skip int) string {
+	_, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s:%d", filepath.Join(filepath.Base(filepath.Dir(file)), filepath.Base(file)), line)
+}
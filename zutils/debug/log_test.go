@@ -0,0 +1,92 @@
+package d_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/require"
+
+	d "zigchain/zutils/debug"
+)
+
+func TestNewLogger_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := d.NewLogger(&buf, d.Config{Level: "debug", Format: d.FormatJSON})
+	require.NoError(t, err)
+
+	logger.With(log.ModuleKey, "dex").Debug("pool created", "pool_id", "zp1")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "debug", entry["level"])
+	require.Equal(t, "pool created", entry["message"])
+	require.Equal(t, "dex", entry[log.ModuleKey])
+	require.Equal(t, "zp1", entry["pool_id"])
+}
+
+func TestNewLogger_Plain(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := d.NewLogger(&buf, d.Config{Level: "info", Format: "PLAIN"})
+	require.NoError(t, err)
+
+	logger.Debug("filtered out")
+	logger.Info("kept", "key", "value")
+
+	out := buf.String()
+	require.NotContains(t, out, "filtered out")
+	require.Contains(t, out, "kept")
+	require.Contains(t, out, "key=value")
+	// no color escape codes
+	require.NotContains(t, out, "\x1b[")
+}
+
+func TestNewLogger_InvalidConfig(t *testing.T) {
+	_, err := d.NewLogger(&bytes.Buffer{}, d.Config{Level: "verbose", Format: d.FormatPlain})
+	require.ErrorContains(t, err, d.EnvLogLevel)
+
+	_, err = d.NewLogger(&bytes.Buffer{}, d.Config{Level: "debug", Format: "xml"})
+	require.ErrorContains(t, err, d.EnvLogFormat)
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(d.EnvLogLevel, "")
+	t.Setenv(d.EnvLogFormat, "")
+	require.Equal(t, d.Config{Level: d.DefaultLevel, Format: d.FormatPlain}, d.ConfigFromEnv())
+
+	t.Setenv(d.EnvLogLevel, "dex:debug,*:error")
+	t.Setenv(d.EnvLogFormat, d.FormatJSON)
+	require.Equal(t, d.Config{Level: "dex:debug,*:error", Format: d.FormatJSON}, d.ConfigFromEnv())
+}
+
+func TestDebug_Caller(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := d.NewLogger(&buf, d.Config{Level: "debug", Format: d.FormatJSON})
+	require.NoError(t, err)
+	d.SetLogger(logger)
+	t.Cleanup(func() { d.SetLogger(nil) })
+
+	keyVals := make([]interface{}, 2, 4)
+	keyVals[0], keyVals[1] = "height", 10
+	d.Debug("step", keyVals...)
+	d.P("value")
+
+	// the slice of the caller is not written
+	require.Equal(t, []interface{}{"height", 10, nil, nil}, keyVals[:cap(keyVals)])
+
+	if !d.Enabled {
+		// without the zdebug tag nothing is logged
+		require.Empty(t, buf.String())
+		return
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		require.Regexp(t, `^debug/log_test\.go:\d+$`, entry[d.CallerKey])
+	}
+}
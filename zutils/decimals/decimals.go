@@ -0,0 +1,139 @@
+package decimals
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"zigchain/zutils/constants"
+	"zigchain/zutils/validators"
+)
+
+// pow10 holds the powers of ten from 10^0 to 10^MaxDecimalDifference,
+// so scaling never has to compute them on the fly.
+var pow10 [constants.MaxDecimalDifference + 1]sdkmath.Int
+
+func init() {
+	pow10[0] = sdkmath.OneInt()
+	for i := 1; i < len(pow10); i++ {
+		pow10[i] = pow10[i-1].MulRaw(10)
+	}
+}
+
+// Pow10 returns 10^diff for a valid decimal difference.
+func Pow10(diff uint32) (sdkmath.Int, error) {
+	if err := validators.ValidateDecimalDifference(diff); err != nil {
+		return sdkmath.Int{}, err
+	}
+	return pow10[diff], nil
+}
+
+// ScaleUp converts an amount to a denom with diff more decimal places,
+// e.g. 1 uzig (6 decimals) -> 10^12 units of an 18 decimals denom.
+//
+// Parameters:
+//   - amount: the amount to scale, must be non-nil and non-negative.
+//   - diff: the decimal difference, validated with validators.ValidateDecimalDifference.
+//
+// Returns:
+//   - sdkmath.Int: amount * 10^diff.
+//   - error: if the amount or the difference is invalid, or the result overflows sdkmath.Int.
+func ScaleUp(amount sdkmath.Int, diff uint32) (sdkmath.Int, error) {
+	if err := checkAmount(amount); err != nil {
+		return sdkmath.Int{}, err
+	}
+
+	factor, err := Pow10(diff)
+	if err != nil {
+		return sdkmath.Int{}, err
+	}
+
+	scaled, err := amount.SafeMul(factor)
+	if err != nil {
+		return sdkmath.Int{}, errorsmod.Wrapf(
+			sdkerrors.ErrInvalidCoins,
+			"amount %s overflows when scaled up by %d decimals (%s)",
+			amount.String(),
+			diff,
+			err,
+		)
+	}
+
+	return scaled, nil
+}
+
+// ScaleDown converts an amount to a denom with diff fewer decimal places,
+// e.g. 10^12 + 5 units of an 18 decimals denom -> 1 uzig (6 decimals) and a dust of 5.
+//
+// The result is always rounded down. The part that cannot be represented in the
+// target precision is returned as dust, expressed in the units of the source denom,
+// so that scaled * 10^diff + dust == amount. Callers decide what to do with the dust.
+//
+// Parameters:
+//   - amount: the amount to scale, must be non-nil and non-negative.
+//   - diff: the decimal difference, validated with validators.ValidateDecimalDifference.
+//
+// Returns:
+//   - scaled: amount / 10^diff, rounded down.
+//   - dust: amount % 10^diff.
+//   - error: if the amount or the difference is invalid.
+func ScaleDown(amount sdkmath.Int, diff uint32) (scaled sdkmath.Int, dust sdkmath.Int, err error) {
+	if err := checkAmount(amount); err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	factor, err := Pow10(diff)
+	if err != nil {
+		return sdkmath.Int{}, sdkmath.Int{}, err
+	}
+
+	return amount.Quo(factor), amount.Mod(factor), nil
+}
+
+// Convert converts an amount between two denoms with the given number of decimal places.
+//
+// Converting to a denom with more decimals never produces dust. Converting to a denom
+// with fewer decimals rounds down and returns the remainder as dust in the units of the
+// source denom, see ScaleDown.
+//
+// Parameters:
+//   - amount: the amount in the source denom, must be non-nil and non-negative.
+//   - fromDecimals: decimal places of the source denom.
+//   - toDecimals: decimal places of the target denom.
+//
+// Returns:
+//   - converted: the amount in the target denom.
+//   - dust: the part of amount that could not be converted, in the source denom.
+//   - error: if the amount is invalid, the decimal difference exceeds constants.MaxDecimalDifference
+//     or the result overflows sdkmath.Int.
+func Convert(amount sdkmath.Int, fromDecimals uint32, toDecimals uint32) (converted sdkmath.Int, dust sdkmath.Int, err error) {
+	if toDecimals >= fromDecimals {
+		converted, err = ScaleUp(amount, toDecimals-fromDecimals)
+		if err != nil {
+			return sdkmath.Int{}, sdkmath.Int{}, err
+		}
+		return converted, sdkmath.ZeroInt(), nil
+	}
+
+	return ScaleDown(amount, fromDecimals-toDecimals)
+}
+
+// checkAmount ensures the amount can be scaled.
+func checkAmount(amount sdkmath.Int) error {
+	if amount.IsNil() {
+		return errorsmod.Wrap(
+			sdkerrors.ErrInvalidCoins,
+			"invalid amount: cannot be nil",
+		)
+	}
+
+	if amount.IsNegative() {
+		return errorsmod.Wrapf(
+			sdkerrors.ErrInvalidCoins,
+			"invalid amount: %s cannot be negative",
+			amount.String(),
+		)
+	}
+
+	return nil
+}
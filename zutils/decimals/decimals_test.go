@@ -0,0 +1,230 @@
+package decimals_test
+
+import (
+	// the round trip property test draws amounts from a fixed seed, so a failure is reproducible
+	"math/rand"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/constants"
+	"zigchain/zutils/decimals"
+)
+
+func TestPow10(t *testing.T) {
+	p, err := decimals.Pow10(0)
+	require.NoError(t, err)
+	require.Equal(t, sdkmath.OneInt().String(), p.String())
+
+	p, err = decimals.Pow10(constants.MaxDecimalDifference)
+	require.NoError(t, err)
+	require.Equal(t, sdkmath.NewIntWithDecimal(1, constants.MaxDecimalDifference).String(), p.String())
+
+	_, err = decimals.Pow10(constants.MaxDecimalDifference + 1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "decimal difference cannot be greater than 18")
+}
+
+func TestScaleUp(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   sdkmath.Int
+		diff     uint32
+		expected sdkmath.Int
+	}{
+		{
+			name:     "no difference",
+			amount:   sdkmath.NewInt(123),
+			diff:     0,
+			expected: sdkmath.NewInt(123),
+		},
+		{
+			name:     "uzig to 18 decimals",
+			amount:   sdkmath.NewInt(1),
+			diff:     12,
+			expected: sdkmath.NewInt(1_000_000_000_000),
+		},
+		{
+			name:     "zero",
+			amount:   sdkmath.ZeroInt(),
+			diff:     18,
+			expected: sdkmath.ZeroInt(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scaled, err := decimals.ScaleUp(tt.amount, tt.diff)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected.String(), scaled.String())
+		})
+	}
+}
+
+func TestScaleUp_Invalid(t *testing.T) {
+	_, err := decimals.ScaleUp(sdkmath.Int{}, 6)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+	require.Contains(t, err.Error(), "cannot be nil")
+
+	_, err = decimals.ScaleUp(sdkmath.NewInt(-1), 6)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+	require.Contains(t, err.Error(), "cannot be negative")
+
+	_, err = decimals.ScaleUp(sdkmath.NewInt(1), 19)
+	require.Error(t, err)
+
+	// the largest 256 bit value cannot be scaled up without overflowing
+	maxInt, ok := sdkmath.NewIntFromString("115792089237316195423570985008687907853269984665640564039457584007913129639935")
+	require.True(t, ok)
+	_, err = decimals.ScaleUp(maxInt, 1)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+	require.Contains(t, err.Error(), "overflows")
+}
+
+func TestScaleDown(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount sdkmath.Int
+		diff   uint32
+		scaled sdkmath.Int
+		dust   sdkmath.Int
+	}{
+		{
+			name:   "no difference",
+			amount: sdkmath.NewInt(123),
+			diff:   0,
+			scaled: sdkmath.NewInt(123),
+			dust:   sdkmath.ZeroInt(),
+		},
+		{
+			name:   "exact",
+			amount: sdkmath.NewInt(2_000_000_000_000),
+			diff:   12,
+			scaled: sdkmath.NewInt(2),
+			dust:   sdkmath.ZeroInt(),
+		},
+		{
+			name:   "with dust",
+			amount: sdkmath.NewInt(1_000_000_000_005),
+			diff:   12,
+			scaled: sdkmath.NewInt(1),
+			dust:   sdkmath.NewInt(5),
+		},
+		{
+			name:   "only dust",
+			amount: sdkmath.NewInt(999_999_999_999),
+			diff:   12,
+			scaled: sdkmath.ZeroInt(),
+			dust:   sdkmath.NewInt(999_999_999_999),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scaled, dust, err := decimals.ScaleDown(tt.amount, tt.diff)
+			require.NoError(t, err)
+			require.Equal(t, tt.scaled.String(), scaled.String())
+			require.Equal(t, tt.dust.String(), dust.String())
+		})
+	}
+}
+
+func TestScaleDown_Invalid(t *testing.T) {
+	_, _, err := decimals.ScaleDown(sdkmath.Int{}, 6)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+
+	_, _, err = decimals.ScaleDown(sdkmath.NewInt(-10), 6)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+
+	_, _, err = decimals.ScaleDown(sdkmath.NewInt(10), 19)
+	require.Error(t, err)
+}
+
+func TestConvert(t *testing.T) {
+	// 18 decimals unit-zig -> 6 decimals uzig
+	converted, dust, err := decimals.Convert(sdkmath.NewInt(1_500_000_000_000_000_001), 18, constants.BondDenomDecimals)
+	require.NoError(t, err)
+	require.Equal(t, sdkmath.NewInt(1_500_000).String(), converted.String())
+	require.Equal(t, sdkmath.NewInt(1).String(), dust.String())
+
+	// 6 decimals uzig -> 18 decimals unit-zig
+	converted, dust, err = decimals.Convert(sdkmath.NewInt(1_500_000), constants.BondDenomDecimals, 18)
+	require.NoError(t, err)
+	require.Equal(t, sdkmath.NewInt(1_500_000_000_000_000_000).String(), converted.String())
+	require.True(t, dust.IsZero())
+
+	// same precision
+	converted, dust, err = decimals.Convert(sdkmath.NewInt(42), 6, 6)
+	require.NoError(t, err)
+	require.Equal(t, sdkmath.NewInt(42).String(), converted.String())
+	require.True(t, dust.IsZero())
+
+	// difference too large in both directions
+	_, _, err = decimals.Convert(sdkmath.NewInt(1), 0, 19)
+	require.Error(t, err)
+	_, _, err = decimals.Convert(sdkmath.NewInt(1), 19, 0)
+	require.Error(t, err)
+}
+
+// randomInt returns a random non-negative amount of up to maxBits bits.
+func randomInt(r *rand.Rand, maxBits int) sdkmath.Int {
+	amount := sdkmath.ZeroInt()
+	for bits := r.Intn(maxBits + 1); bits > 0; bits -= 32 {
+		amount = amount.MulRaw(1 << 32).AddRaw(int64(r.Uint32()))
+	}
+	return amount
+}
+
+func TestScale_RoundTripProperty(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 10_000; i++ {
+		diff := uint32(r.Intn(constants.MaxDecimalDifference + 1))
+		// keep room for 10^18 (< 2^60) so scaling up never overflows
+		amount := randomInt(r, 192)
+
+		// up then down is lossless
+		up, err := decimals.ScaleUp(amount, diff)
+		require.NoError(t, err)
+		down, dust, err := decimals.ScaleDown(up, diff)
+		require.NoError(t, err)
+		require.Equal(t, amount.String(), down.String(), "amount %s diff %d", amount, diff)
+		require.True(t, dust.IsZero(), "amount %s diff %d", amount, diff)
+
+		// down then up restores the amount once the dust is added back
+		down, dust, err = decimals.ScaleDown(amount, diff)
+		require.NoError(t, err)
+		up, err = decimals.ScaleUp(down, diff)
+		require.NoError(t, err)
+		require.Equal(t, amount.String(), up.Add(dust).String(), "amount %s diff %d", amount, diff)
+
+		// dust is always smaller than one unit of the target denom
+		factor, err := decimals.Pow10(diff)
+		require.NoError(t, err)
+		require.True(t, dust.LT(factor), "amount %s diff %d", amount, diff)
+		require.False(t, dust.IsNegative())
+	}
+}
+
+func FuzzConvert(f *testing.F) {
+	f.Add(uint64(1_500_000_000_000_000_001), uint32(18), uint32(6))
+	f.Add(uint64(1), uint32(6), uint32(18))
+	f.Add(uint64(0), uint32(0), uint32(0))
+
+	f.Fuzz(func(t *testing.T, raw uint64, from uint32, to uint32) {
+		from %= constants.MaxDecimalDifference + 1
+		to %= constants.MaxDecimalDifference + 1
+		amount := sdkmath.NewIntFromUint64(raw)
+
+		converted, dust, err := decimals.Convert(amount, from, to)
+		require.NoError(t, err)
+
+		// converting back and adding the dust always yields the original amount
+		back, backDust, err := decimals.Convert(converted, to, from)
+		require.NoError(t, err)
+		require.True(t, backDust.IsZero())
+		require.Equal(t, amount.String(), back.Add(dust).String())
+	})
+}
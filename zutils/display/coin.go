@@ -0,0 +1,168 @@
+package display
+
+import (
+	"regexp"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"zigchain/zutils/validators"
+)
+
+var (
+	// amount with optional fraction followed by a denom, e.g. "1,500.25 zig" or "1500000uzig"
+	displayCoinRegex = regexp.MustCompile(`^([0-9][0-9,]*)(?:\.([0-9]+))?\s*([a-zA-Z][a-zA-Z0-9/:._-]*)$`)
+	// integer part with correctly placed thousands separators, e.g. "1,500,000"
+	groupedIntRegex = regexp.MustCompile(`^[0-9]{1,3}(,[0-9]{3})*$`)
+)
+
+// FormatOptions controls how FormatDisplayCoin renders an amount.
+type FormatOptions struct {
+	// Precision is the maximum number of fractional digits. Extra digits are truncated (rounded down),
+	// so the displayed amount never exceeds the actual amount. A negative value shows all digits.
+	Precision int
+	// ThousandsSeparator is inserted between groups of three integer digits. Empty disables grouping.
+	ThousandsSeparator string
+	// TrimTrailingZeros removes trailing zeros from the fraction, and the decimal point if nothing is left.
+	TrimTrailingZeros bool
+}
+
+// DefaultFormatOptions shows all significant fractional digits and groups thousands with a comma,
+// e.g. 1234567500000uzig -> 1,234,567.5zig
+var DefaultFormatOptions = FormatOptions{
+	Precision:          -1,
+	ThousandsSeparator: ",",
+	TrimTrailingZeros:  true,
+}
+
+// ParseDisplayCoin parses a human-readable coin, e.g. "1.5zig", into a coin in the base denom, e.g. 1500000uzig.
+//
+// The amount may use commas as thousands separators ("1,500.25zig") and may be separated from the denom by spaces.
+// Denoms that are not registered as display denoms are taken as base denoms and must not have a fraction.
+//
+// Returns:
+//   - sdk.Coin: the coin in the base denom, validated with validators.CoinCheck.
+//   - error: if the string is malformed, has more fractional digits than the denom supports or the coin is invalid.
+func (r *Registry) ParseDisplayCoin(coinStr string) (sdk.Coin, error) {
+	coinStr = strings.TrimSpace(coinStr)
+
+	matches := displayCoinRegex.FindStringSubmatch(coinStr)
+	if matches == nil {
+		return sdk.Coin{}, errorsmod.Wrapf(
+			sdkerrors.ErrInvalidCoins,
+			"invalid coin: '%s' has to be an amount followed by a denom e.g. 1.5zig",
+			coinStr,
+		)
+	}
+	intPart, fracPart, denom := matches[1], matches[2], matches[3]
+
+	if strings.Contains(intPart, ",") {
+		if !groupedIntRegex.MatchString(intPart) {
+			return sdk.Coin{}, errorsmod.Wrapf(
+				sdkerrors.ErrInvalidCoins,
+				"invalid coin: '%s' has misplaced thousands separators e.g. 1,500.5zig",
+				coinStr,
+			)
+		}
+		intPart = strings.ReplaceAll(intPart, ",", "")
+	}
+
+	baseDenom, exponent := denom, uint32(0)
+	if u, ok := r.ByDisplay(denom); ok {
+		baseDenom, exponent = u.Base, u.Exponent
+	}
+
+	if len(fracPart) > int(exponent) {
+		return sdk.Coin{}, errorsmod.Wrapf(
+			sdkerrors.ErrInvalidCoins,
+			"invalid coin: '%s' has %d fractional digits, maximum %d for %s",
+			coinStr,
+			len(fracPart),
+			exponent,
+			denom,
+		)
+	}
+
+	fracPart += strings.Repeat("0", int(exponent)-len(fracPart))
+
+	amount, ok := sdkmath.NewIntFromString(intPart + fracPart)
+	if !ok {
+		return sdk.Coin{}, errorsmod.Wrapf(
+			sdkerrors.ErrInvalidCoins,
+			"invalid coin: '%s' amount is out of range",
+			coinStr,
+		)
+	}
+
+	coin := sdk.Coin{Denom: baseDenom, Amount: amount}
+	if err := validators.CoinCheck(coin, true); err != nil {
+		return sdk.Coin{}, err
+	}
+
+	return coin, nil
+}
+
+// FormatDisplayCoin renders a coin in its display denom, e.g. 1500000uzig -> 1.5zig.
+// Coins whose denom is not registered are rendered in their own denom.
+//
+// Returns:
+//   - string: the formatted coin.
+//   - error: if the coin fails validators.CoinCheck.
+func (r *Registry) FormatDisplayCoin(coin sdk.Coin, opts FormatOptions) (string, error) {
+	if err := validators.CoinCheck(coin, true); err != nil {
+		return "", err
+	}
+
+	denom, exponent := coin.Denom, 0
+	if u, ok := r.ByBase(coin.Denom); ok {
+		denom, exponent = u.Display, int(u.Exponent)
+	}
+
+	digits := coin.Amount.String()
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	intPart, fracPart := digits[:len(digits)-exponent], digits[len(digits)-exponent:]
+
+	if opts.Precision >= 0 && opts.Precision < len(fracPart) {
+		fracPart = fracPart[:opts.Precision]
+	}
+
+	if opts.TrimTrailingZeros {
+		fracPart = strings.TrimRight(fracPart, "0")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(groupThousands(intPart, opts.ThousandsSeparator))
+	if fracPart != "" {
+		sb.WriteString(".")
+		sb.WriteString(fracPart)
+	}
+	sb.WriteString(denom)
+
+	return sb.String(), nil
+}
+
+// groupThousands inserts the separator between groups of three digits, counted from the right.
+func groupThousands(digits string, separator string) string {
+	if separator == "" || len(digits) <= 3 {
+		return digits
+	}
+
+	var sb strings.Builder
+	head := len(digits) % 3
+	if head > 0 {
+		sb.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += 3 {
+		if sb.Len() > 0 {
+			sb.WriteString(separator)
+		}
+		sb.WriteString(digits[i : i+3])
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,186 @@
+package display_test
+
+import (
+	"strings"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/display"
+)
+
+func TestParseDisplayCoin(t *testing.T) {
+	r := newTestRegistry(t)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "1.5zig", expected: "1500000uzig"},
+		{input: "1zig", expected: "1000000uzig"},
+		{input: "0.000001zig", expected: "1uzig"},
+		{input: "0zig", expected: "0uzig"},
+		{input: "1,500.25zig", expected: "1500250000uzig"},
+		{input: "  2.5 zig ", expected: "2500000uzig"},
+		{input: "1500000uzig", expected: "1500000uzig"},
+		{input: "1.000000000000000001axlzig", expected: "1000000000000000001unit-zig"},
+		{input: "10abc", expected: "10abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			coin, err := r.ParseDisplayCoin(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, coin.String())
+		})
+	}
+}
+
+func TestParseDisplayCoin_Invalid(t *testing.T) {
+	r := newTestRegistry(t)
+
+	tests := []struct {
+		input  string
+		errMsg string
+	}{
+		{input: "", errMsg: "has to be an amount followed by a denom"},
+		{input: "zig", errMsg: "has to be an amount followed by a denom"},
+		{input: "1.5", errMsg: "has to be an amount followed by a denom"},
+		{input: "-1zig", errMsg: "has to be an amount followed by a denom"},
+		{input: "1.zig", errMsg: "has to be an amount followed by a denom"},
+		{input: ".5zig", errMsg: "has to be an amount followed by a denom"},
+		{input: "1,50zig", errMsg: "misplaced thousands separators"},
+		{input: "1500,000zig", errMsg: "misplaced thousands separators"},
+		{input: "1.0000001zig", errMsg: "has 7 fractional digits, maximum 6 for zig"},
+		{input: "1.5uzig", errMsg: "has 1 fractional digits, maximum 0 for uzig"},
+		{input: "1" + strings.Repeat("0", 80) + "uzig", errMsg: "amount is out of range"},
+		{input: "1ab", errMsg: "denom name is too short"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := r.ParseDisplayCoin(tt.input)
+			require.Error(t, err)
+			require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+			require.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestFormatDisplayCoin(t *testing.T) {
+	r := newTestRegistry(t)
+
+	tests := []struct {
+		name     string
+		coin     sdk.Coin
+		opts     display.FormatOptions
+		expected string
+	}{
+		{
+			name:     "default",
+			coin:     sdk.NewInt64Coin("uzig", 1_500_000),
+			opts:     display.DefaultFormatOptions,
+			expected: "1.5zig",
+		},
+		{
+			name:     "default with thousands",
+			coin:     sdk.NewInt64Coin("uzig", 1_234_567_500_000),
+			opts:     display.DefaultFormatOptions,
+			expected: "1,234,567.5zig",
+		},
+		{
+			name:     "default whole amount",
+			coin:     sdk.NewInt64Coin("uzig", 3_000_000),
+			opts:     display.DefaultFormatOptions,
+			expected: "3zig",
+		},
+		{
+			name:     "default smaller than one display unit",
+			coin:     sdk.NewInt64Coin("uzig", 1),
+			opts:     display.DefaultFormatOptions,
+			expected: "0.000001zig",
+		},
+		{
+			name:     "zero",
+			coin:     sdk.NewInt64Coin("uzig", 0),
+			opts:     display.DefaultFormatOptions,
+			expected: "0zig",
+		},
+		{
+			name:     "full precision without trimming",
+			coin:     sdk.NewInt64Coin("uzig", 1_500_000),
+			opts:     display.FormatOptions{Precision: -1},
+			expected: "1.500000zig",
+		},
+		{
+			name:     "precision truncates",
+			coin:     sdk.NewInt64Coin("uzig", 1_999_999),
+			opts:     display.FormatOptions{Precision: 2},
+			expected: "1.99zig",
+		},
+		{
+			name:     "precision zero",
+			coin:     sdk.NewInt64Coin("uzig", 1_999_999),
+			opts:     display.FormatOptions{Precision: 0},
+			expected: "1zig",
+		},
+		{
+			name:     "custom separator",
+			coin:     sdk.NewInt64Coin("uzig", 1_234_567_000_000),
+			opts:     display.FormatOptions{Precision: 0, ThousandsSeparator: "_"},
+			expected: "1_234_567zig",
+		},
+		{
+			name:     "18 decimals",
+			coin:     sdk.NewCoin("unit-zig", sdkmath.NewIntWithDecimal(15, 17)),
+			opts:     display.DefaultFormatOptions,
+			expected: "1.5axlzig",
+		},
+		{
+			name:     "unregistered denom",
+			coin:     sdk.NewInt64Coin("abc", 1_234_567),
+			opts:     display.DefaultFormatOptions,
+			expected: "1,234,567abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatted, err := r.FormatDisplayCoin(tt.coin, tt.opts)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, formatted)
+		})
+	}
+}
+
+func TestFormatDisplayCoin_Invalid(t *testing.T) {
+	r := display.DefaultRegistry()
+
+	_, err := r.FormatDisplayCoin(sdk.Coin{Denom: "uzig", Amount: sdkmath.NewInt(-1)}, display.DefaultFormatOptions)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+
+	_, err = r.FormatDisplayCoin(sdk.Coin{Denom: "ab", Amount: sdkmath.NewInt(1)}, display.DefaultFormatOptions)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+}
+
+func TestDisplayCoin_RoundTrip(t *testing.T) {
+	r := newTestRegistry(t)
+
+	for _, coin := range []sdk.Coin{
+		sdk.NewInt64Coin("uzig", 0),
+		sdk.NewInt64Coin("uzig", 1),
+		sdk.NewInt64Coin("uzig", 1_000_000),
+		sdk.NewInt64Coin("uzig", 123_456_789_012),
+		sdk.NewCoin("unit-zig", sdkmath.NewIntWithDecimal(1, 30).AddRaw(7)),
+	} {
+		formatted, err := r.FormatDisplayCoin(coin, display.DefaultFormatOptions)
+		require.NoError(t, err)
+
+		parsed, err := r.ParseDisplayCoin(formatted)
+		require.NoError(t, err)
+		require.Equal(t, coin.String(), parsed.String())
+	}
+}
@@ -0,0 +1,127 @@
+package display
+
+import (
+	"sync"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"zigchain/zutils/constants"
+	"zigchain/zutils/validators"
+)
+
+// DenomUnit maps a base denom to its display denom.
+// One display unit equals 10^Exponent base units, e.g. 1 zig = 10^6 uzig.
+type DenomUnit struct {
+	Base     string
+	Display  string
+	Exponent uint32
+}
+
+// Validate checks both denoms with validators.CheckDenomString and the exponent
+// with validators.ValidateDecimalDifference.
+func (u DenomUnit) Validate() error {
+	if err := validators.CheckDenomString(u.Base); err != nil {
+		return err
+	}
+
+	if err := validators.CheckDenomString(u.Display); err != nil {
+		return err
+	}
+
+	if u.Base == u.Display {
+		return errorsmod.Wrapf(
+			sdkerrors.ErrInvalidCoins,
+			"invalid denom unit: display denom '%s' has to differ from the base denom",
+			u.Display,
+		)
+	}
+
+	return validators.ValidateDecimalDifference(u.Exponent)
+}
+
+// Registry holds the display units of the known denoms and converts between base and display amounts.
+// It is safe for concurrent use.
+type Registry struct {
+	mu        sync.RWMutex
+	byBase    map[string]DenomUnit
+	byDisplay map[string]DenomUnit
+}
+
+// NewRegistry creates a registry with the given units.
+func NewRegistry(units ...DenomUnit) (*Registry, error) {
+	r := &Registry{
+		byBase:    make(map[string]DenomUnit),
+		byDisplay: make(map[string]DenomUnit),
+	}
+
+	for _, u := range units {
+		if err := r.Register(u); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+// DefaultRegistry returns a new registry holding the native coin: constants.BondDisplayDenom
+// with constants.BondDenomDecimals decimals over constants.BondDenom.
+func DefaultRegistry() *Registry {
+	r, err := NewRegistry(DenomUnit{
+		Base:     constants.BondDenom,
+		Display:  constants.BondDisplayDenom,
+		Exponent: constants.BondDenomDecimals,
+	})
+	if err != nil {
+		// the native coin is defined by constants and always valid
+		panic(err)
+	}
+
+	return r
+}
+
+// Register adds a unit to the registry.
+// Neither denom of the unit may already be registered, as base or as display denom.
+func (r *Registry) Register(u DenomUnit) error {
+	if err := u.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, denom := range []string{u.Base, u.Display} {
+		_, isBase := r.byBase[denom]
+		_, isDisplay := r.byDisplay[denom]
+		if isBase || isDisplay {
+			return errorsmod.Wrapf(
+				sdkerrors.ErrInvalidCoins,
+				"invalid denom unit: denom '%s' is already registered",
+				denom,
+			)
+		}
+	}
+
+	r.byBase[u.Base] = u
+	r.byDisplay[u.Display] = u
+
+	return nil
+}
+
+// ByBase returns the unit registered for the base denom.
+func (r *Registry) ByBase(base string) (DenomUnit, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	u, ok := r.byBase[base]
+	return u, ok
+}
+
+// ByDisplay returns the unit registered for the display denom.
+func (r *Registry) ByDisplay(display string) (DenomUnit, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	u, ok := r.byDisplay[display]
+	return u, ok
+}
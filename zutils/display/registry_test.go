@@ -0,0 +1,41 @@
+package display_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/display"
+)
+
+func newTestRegistry(t *testing.T) *display.Registry {
+	t.Helper()
+
+	r := display.DefaultRegistry()
+	require.NoError(t, r.Register(display.DenomUnit{Base: "unit-zig", Display: "axlzig", Exponent: 18}))
+	return r
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := display.DefaultRegistry()
+
+	u, ok := r.ByBase("uzig")
+	require.True(t, ok)
+	require.Equal(t, display.DenomUnit{Base: "uzig", Display: "zig", Exponent: 6}, u)
+
+	u, ok = r.ByDisplay("zig")
+	require.True(t, ok)
+	require.Equal(t, "uzig", u.Base)
+
+	// base and display denoms can only be registered once
+	require.Error(t, r.Register(display.DenomUnit{Base: "uzig", Display: "zig2", Exponent: 6}))
+	require.Error(t, r.Register(display.DenomUnit{Base: "zig", Display: "kzig", Exponent: 3}))
+
+	// invalid units
+	require.Error(t, r.Register(display.DenomUnit{Base: "ab", Display: "abc", Exponent: 6}))
+	require.Error(t, r.Register(display.DenomUnit{Base: "abc", Display: "abc", Exponent: 6}))
+	require.Error(t, r.Register(display.DenomUnit{Base: "uabc", Display: "abc", Exponent: 19}))
+
+	_, err := display.NewRegistry(display.DenomUnit{Base: "uabc", Display: "abc", Exponent: 19})
+	require.Error(t, err)
+}
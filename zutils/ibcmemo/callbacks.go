@@ -0,0 +1,123 @@
+package ibcmemo
+
+import (
+	errorsmod "cosmossdk.io/errors"
+	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+
+	"zigchain/zutils/constants"
+	"zigchain/zutils/validators"
+)
+
+// gasLimitRule caps the gas limit of a callback.
+var gasLimitRule = validators.Uint64().
+	Named("gas_limit").
+	Code(transfertypes.ErrInvalidMemo).
+	Max(constants.MaxIBCCallbackGas)
+
+// CallbackMetadata is an IBC callbacks middleware entry of a memo:
+//
+//	{"src_callback":{"address":"zig1...","gas_limit":"1000000"}}
+//	{"dest_callback":{"address":"zig1...","gas_limit":"1000000"}}
+//
+// The contract at Address is called with the packet lifecycle events: acknowledgement and timeout
+// on the source chain, receive on the destination chain. A zero GasLimit is omitted and lets the
+// middleware use its maximum.
+type CallbackMetadata struct {
+	Address  string `json:"address"`
+	GasLimit uint64 `json:"gas_limit,omitempty,string"`
+}
+
+// NewCallback creates a callback to the contract at address.
+func NewCallback(address string) *CallbackMetadata {
+	return &CallbackMetadata{Address: address}
+}
+
+// WithGasLimit sets the gas limit of the callback, at most constants.MaxIBCCallbackGas.
+func (c *CallbackMetadata) WithGasLimit(gasLimit uint64) *CallbackMetadata {
+	c.GasLimit = gasLimit
+	return c
+}
+
+// SrcMemo returns a memo holding the callback as the source chain callback.
+func (c *CallbackMetadata) SrcMemo() Memo {
+	return Memo{SrcCallback: c}
+}
+
+// DestMemo returns a memo holding the callback as the destination chain callback.
+func (c *CallbackMetadata) DestMemo() Memo {
+	return Memo{DestCallback: c}
+}
+
+// Validate validates a callback to a contract on zigchain, e.g. the src_callback of a transfer sent from zigchain.
+//
+// It performs the following checks:
+// 1. The address passes validators.ContractAddressCheck, the callback runs on this chain.
+// 2. The gas limit is not greater than constants.MaxIBCCallbackGas.
+func (c *CallbackMetadata) Validate() error {
+	if err := validators.ContractAddressCheck(validators.FieldContract, c.Address); err != nil {
+		return err
+	}
+	return gasLimitRule.Validate(c.GasLimit)
+}
+
+// ValidateForeign validates a callback to a contract on another chain, e.g. the dest_callback of a transfer
+// sent from zigchain. The address passes validators.ForeignAddressCheck; the gas limit is capped by the chain
+// running the callback, so it is not checked.
+func (c *CallbackMetadata) ValidateForeign() error {
+	return validators.ForeignAddressCheck(validators.FieldContract, c.Address)
+}
+
+// packetSide tells which callbacks of a memo run on this chain.
+type packetSide int
+
+const (
+	// sideOutgoing is a packet sent by this chain, its src_callback runs here.
+	sideOutgoing packetSide = iota
+	// sideIncoming is a packet received by this chain, its dest_callback runs here.
+	sideIncoming
+	// sideForeign is a packet between two other chains, none of its callbacks runs here.
+	sideForeign
+)
+
+// sideOf returns the side of a packet with the given direction.
+func sideOf(direction validators.PacketDirection) packetSide {
+	if direction == validators.PacketIncoming {
+		return sideIncoming
+	}
+	return sideOutgoing
+}
+
+// next returns the side of the packet forwarded by the receiving chain of a packet on side s:
+// the packet forwarded from a packet received here is sent by this chain.
+func (s packetSide) next() packetSide {
+	if s == sideIncoming {
+		return sideOutgoing
+	}
+	return sideForeign
+}
+
+// validateCallbacks validates the callbacks of a memo on side s, a callback running on this chain
+// with Validate and the others with ValidateForeign.
+func validateCallbacks(src, dest *CallbackMetadata, s packetSide) error {
+	for _, cb := range []struct {
+		key      string
+		callback *CallbackMetadata
+		local    bool
+	}{
+		{SrcCallbackKey, src, s == sideOutgoing},
+		{DestCallbackKey, dest, s == sideIncoming},
+	} {
+		if cb.callback == nil {
+			continue
+		}
+
+		validate := cb.callback.ValidateForeign
+		if cb.local {
+			validate = cb.callback.Validate
+		}
+		if err := validate(); err != nil {
+			return errorsmod.Wrap(err, cb.key)
+		}
+	}
+	return nil
+}
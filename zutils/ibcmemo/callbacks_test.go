@@ -0,0 +1,201 @@
+package ibcmemo_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/testutil/sample"
+	"zigchain/zutils/constants"
+	"zigchain/zutils/ibcmemo"
+	"zigchain/zutils/validators"
+)
+
+// contractAddress returns a 32-byte zigchain contract address.
+func contractAddress(t *testing.T) string {
+	t.Helper()
+
+	addr, err := bech32.ConvertAndEncode(constants.AddressPrefix, bytes.Repeat([]byte{0x02}, validators.ContractAddressLength))
+	require.NoError(t, err)
+	return addr
+}
+
+func TestCallback_Build(t *testing.T) {
+	contract := contractAddress(t)
+
+	memo, err := ibcmemo.NewCallback(contract).WithGasLimit(1_000_000).SrcMemo().Build()
+	require.NoError(t, err)
+	require.Equal(t, `{"src_callback":{"address":"`+contract+`","gas_limit":"1000000"}}`, memo)
+
+	// the gas limit is optional, the dest_callback of a transfer sent from zigchain runs on the other chain
+	osmoContract := osmoAddress(t)
+	memo, err = ibcmemo.NewCallback(osmoContract).DestMemo().Build()
+	require.NoError(t, err)
+	require.Equal(t, `{"dest_callback":{"address":"`+osmoContract+`"}}`, memo)
+}
+
+func TestCallback_CombinedWithForward(t *testing.T) {
+	contract := contractAddress(t)
+	receiver := osmoAddress(t)
+
+	memo := ibcmemo.NewForward(receiver, "transfer", "channel-1").
+		WithTimeout(time.Minute).
+		Memo().
+		WithSrcCallback(ibcmemo.NewCallback(contract).WithGasLimit(constants.MaxIBCCallbackGas)).
+		WithDestCallback(ibcmemo.NewCallback(receiver))
+
+	built, err := memo.Build()
+	require.NoError(t, err)
+
+	parsed, err := ibcmemo.ParseMemo(built)
+	require.NoError(t, err)
+	require.Equal(t, memo, parsed)
+	require.Nil(t, parsed.Extra)
+}
+
+func TestCallback_Parse(t *testing.T) {
+	contract := contractAddress(t)
+
+	parsed, err := ibcmemo.ParseMemo(`{"dest_callback":{"address":"` + contract + `","gas_limit":"250000"},"wasm":{}}`)
+	require.NoError(t, err)
+	require.Equal(t, ibcmemo.NewCallback(contract).WithGasLimit(250_000), parsed.DestCallback)
+	require.Nil(t, parsed.SrcCallback)
+	require.Contains(t, parsed.Extra, "wasm")
+
+	// the middleware encodes the gas limit as a string
+	_, err = ibcmemo.ParseMemo(`{"src_callback":{"address":"` + contract + `","gas_limit":250000}}`)
+	require.ErrorIs(t, err, transfertypes.ErrInvalidMemo)
+}
+
+func TestCallback_Invalid(t *testing.T) {
+	contract := contractAddress(t)
+
+	testCases := []struct {
+		desc     string
+		callback *ibcmemo.CallbackMetadata
+		rule     string
+		msg      string
+	}{
+		{
+			desc:     "empty address",
+			callback: ibcmemo.NewCallback(""),
+			rule:     validators.RuleAddressEmpty,
+		},
+		{
+			desc:     "address with bad checksum",
+			callback: ibcmemo.NewCallback(contract[:len(contract)-1] + "q"),
+			rule:     validators.RuleAddressInvalidBech32,
+		},
+		{
+			desc:     "user account",
+			callback: ibcmemo.NewCallback(sample.AccAddress()),
+			rule:     validators.RuleAddressNotContract,
+		},
+		{
+			desc:     "contract of another chain",
+			callback: ibcmemo.NewCallback(osmoAddress(t)),
+			rule:     validators.RuleAddressInvalidPrefix,
+		},
+		{
+			desc:     "gas limit too large",
+			callback: ibcmemo.NewCallback(contract).WithGasLimit(constants.MaxIBCCallbackGas + 1),
+			rule:     "gas_limit.too_large",
+			msg:      "src_callback: gas_limit cannot be greater than 10000000, got 10000001: invalid memo",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.callback.SrcMemo().Build()
+			require.Error(t, err)
+
+			if tc.rule != "" {
+				var vErr *validators.ValidationError
+				require.True(t, errors.As(err, &vErr))
+				require.Equal(t, tc.rule, vErr.Rule)
+			}
+			if tc.msg != "" {
+				require.Equal(t, tc.msg, err.Error())
+			}
+		})
+	}
+}
+
+func TestCallback_Direction(t *testing.T) {
+	contract := contractAddress(t)
+	osmoContract := osmoAddress(t)
+
+	testCases := []struct {
+		desc      string
+		memo      ibcmemo.Memo
+		direction validators.PacketDirection
+		rule      string
+	}{
+		{
+			desc:      "outgoing with local source and foreign destination",
+			memo:      ibcmemo.NewCallback(contract).SrcMemo().WithDestCallback(ibcmemo.NewCallback(osmoContract)),
+			direction: validators.PacketOutgoing,
+		},
+		{
+			desc:      "outgoing with foreign source",
+			memo:      ibcmemo.NewCallback(osmoContract).SrcMemo(),
+			direction: validators.PacketOutgoing,
+			rule:      validators.RuleAddressInvalidPrefix,
+		},
+		{
+			desc:      "incoming with foreign source and local destination",
+			memo:      ibcmemo.NewCallback(osmoContract).SrcMemo().WithDestCallback(ibcmemo.NewCallback(contract)),
+			direction: validators.PacketIncoming,
+		},
+		{
+			desc:      "incoming with foreign destination",
+			memo:      ibcmemo.NewCallback(osmoContract).DestMemo(),
+			direction: validators.PacketIncoming,
+			rule:      validators.RuleAddressInvalidPrefix,
+		},
+		{
+			desc:      "incoming with user account destination",
+			memo:      ibcmemo.NewCallback(sample.AccAddress()).DestMemo(),
+			direction: validators.PacketIncoming,
+			rule:      validators.RuleAddressNotContract,
+		},
+		{
+			desc: "incoming forwarded by zigchain with foreign source",
+			memo: ibcmemo.NewForward(osmoAddress(t), "transfer", "channel-1").
+				WithNext(ibcmemo.NewCallback(osmoContract).SrcMemo()).
+				Memo(),
+			direction: validators.PacketIncoming,
+			rule:      validators.RuleAddressInvalidPrefix,
+		},
+		{
+			desc: "outgoing forwarded by another chain",
+			memo: ibcmemo.NewForward(osmoAddress(t), "transfer", "channel-1").
+				WithNext(ibcmemo.NewCallback(osmoContract).SrcMemo().WithDestCallback(ibcmemo.NewCallback(osmoContract))).
+				Memo(),
+			direction: validators.PacketOutgoing,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.memo.ValidateFor(tc.direction)
+
+			_, parseErr := ibcmemo.ParseMemoFor(tc.memo.String(), tc.direction)
+			require.Equal(t, err == nil, parseErr == nil)
+
+			if tc.rule == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var vErr *validators.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, tc.rule, vErr.Rule)
+		})
+	}
+}
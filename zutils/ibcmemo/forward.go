@@ -0,0 +1,155 @@
+package ibcmemo
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+
+	"zigchain/zutils/constants"
+	"zigchain/zutils/validators"
+)
+
+// ForwardMetadata is the packet-forward-middleware entry of a memo:
+//
+//	{"forward":{"receiver":"...","port":"transfer","channel":"channel-0","timeout":"10m0s","retries":2,"next":{...}}}
+//
+// The tokens received by the intermediate chain are sent on through Port and Channel to Receiver.
+// Next is the memo of the forwarded transfer, e.g. another forward.
+type ForwardMetadata struct {
+	Receiver string   `json:"receiver"`
+	Port     string   `json:"port"`
+	Channel  string   `json:"channel"`
+	Timeout  Duration `json:"timeout,omitempty"`
+	Retries  *uint8   `json:"retries,omitempty"`
+	Next     *Memo    `json:"next,omitempty"`
+}
+
+// NewForward creates a forward to receiver through the given port and channel of the intermediate chain.
+func NewForward(receiver, port, channel string) *ForwardMetadata {
+	return &ForwardMetadata{
+		Receiver: receiver,
+		Port:     port,
+		Channel:  channel,
+	}
+}
+
+// WithTimeout sets the timeout of the forwarded transfer, the middleware default is used when unset.
+func (f *ForwardMetadata) WithTimeout(timeout time.Duration) *ForwardMetadata {
+	f.Timeout = Duration(timeout)
+	return f
+}
+
+// WithRetries sets how many times the forward is retried on timeout, the middleware default is used when unset.
+func (f *ForwardMetadata) WithRetries(retries uint8) *ForwardMetadata {
+	f.Retries = &retries
+	return f
+}
+
+// WithNext sets the memo of the forwarded transfer.
+func (f *ForwardMetadata) WithNext(next Memo) *ForwardMetadata {
+	f.Next = &next
+	return f
+}
+
+// Memo returns a memo holding the forward.
+func (f *ForwardMetadata) Memo() Memo {
+	return Memo{Forward: f}
+}
+
+// Hops returns the number of forwards, this one included.
+func (f *ForwardMetadata) Hops() int {
+	hops := 0
+	for next := f; next != nil; {
+		hops++
+		if next.Next == nil {
+			break
+		}
+		next = next.Next.Forward
+	}
+	return hops
+}
+
+// Validate validates the forward and every nested memo.
+//
+// It performs the following checks for every hop:
+// 1. The receiver passes validators.AddressCheck for zigchain addresses and validators.ForeignAddressCheck otherwise.
+// 2. The port and channel pass validators.ValidatePort and validators.ValidateChannel.
+// 3. The timeout is not negative.
+// 4. There are at most constants.MaxForwardHops nested forwards.
+//
+// The forward is the one of a transfer sent from this chain, see Memo.ValidateFor for the nested callbacks.
+func (f *ForwardMetadata) Validate() error {
+	return f.validate(sideOutgoing, 0)
+}
+
+func (f *ForwardMetadata) validate(side packetSide, depth int) error {
+	if depth >= constants.MaxForwardHops {
+		return errorsmod.Wrapf(
+			transfertypes.ErrInvalidMemo,
+			"forward memo: cannot have more than %d nested forwards",
+			constants.MaxForwardHops,
+		)
+	}
+
+	hop := depth + 1
+
+	if err := checkMemoAddress(validators.FieldReceiver, f.Receiver); err != nil {
+		return errorsmod.Wrapf(err, "forward hop %d", hop)
+	}
+
+	if err := validators.ValidatePort(f.Port); err != nil {
+		return errorsmod.Wrapf(err, "forward hop %d", hop)
+	}
+
+	if err := validators.ValidateChannel(f.Channel); err != nil {
+		return errorsmod.Wrapf(err, "forward hop %d", hop)
+	}
+
+	if f.Timeout < 0 {
+		return errorsmod.Wrapf(
+			transfertypes.ErrInvalidMemo,
+			"forward hop %d: timeout %s cannot be negative",
+			hop,
+			time.Duration(f.Timeout),
+		)
+	}
+
+	if f.Next != nil {
+		return f.Next.validate(side.next(), hop)
+	}
+
+	return nil
+}
+
+// Duration is a time.Duration encoded as in the packet-forward-middleware memo:
+// written as a string such as "10m0s", read from a string or from a number of nanoseconds.
+type Duration time.Duration
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		parsed, err := time.ParseDuration(text)
+		if err != nil {
+			return err
+		}
+		*d = Duration(parsed)
+		return nil
+	}
+
+	var nanos int64
+	if err := json.Unmarshal(data, &nanos); err != nil {
+		return fmt.Errorf("invalid duration: %s", data)
+	}
+	*d = Duration(nanos)
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package ibcmemo_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/testutil/sample"
+	"zigchain/zutils/constants"
+	"zigchain/zutils/ibcmemo"
+	"zigchain/zutils/validators"
+)
+
+func osmoAddress(t *testing.T) string {
+	t.Helper()
+
+	addr, err := bech32.ConvertAndEncode("osmo", bytes.Repeat([]byte{0x01}, 20))
+	require.NoError(t, err)
+	return addr
+}
+
+func TestForward_Build(t *testing.T) {
+	receiver := osmoAddress(t)
+
+	memo, err := ibcmemo.NewForward(receiver, "transfer", "channel-1").
+		WithTimeout(10 * time.Minute).
+		WithRetries(2).
+		Memo().
+		Build()
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		`{"forward":{"receiver":"`+receiver+`","port":"transfer","channel":"channel-1","timeout":"10m0s","retries":2}}`,
+		memo,
+	)
+
+	// timeout and retries are optional
+	memo, err = ibcmemo.NewForward(receiver, "transfer", "channel-1").Memo().Build()
+	require.NoError(t, err)
+	require.Equal(t, `{"forward":{"receiver":"`+receiver+`","port":"transfer","channel":"channel-1"}}`, memo)
+}
+
+func TestForward_RoundTrip(t *testing.T) {
+	receiver := osmoAddress(t)
+
+	forward := ibcmemo.NewForward("pfm", "transfer", "channel-1").
+		WithNext(ibcmemo.NewForward(receiver, "transfer", "channel-7").WithRetries(0).Memo())
+	// intermediate receivers are usually placeholders, but have to be addresses to be validated
+	forward.Receiver = sample.AccAddress()
+
+	built, err := forward.Memo().Build()
+	require.NoError(t, err)
+
+	parsed, err := ibcmemo.ParseMemo(built)
+	require.NoError(t, err)
+	require.Equal(t, 2, parsed.Forward.Hops())
+	require.Equal(t, "channel-7", parsed.Forward.Next.Forward.Channel)
+	require.Equal(t, uint8(0), *parsed.Forward.Next.Forward.Retries)
+	require.Equal(t, built, parsed.String())
+}
+
+func TestForward_ParseMiddlewareFormats(t *testing.T) {
+	receiver := osmoAddress(t)
+
+	// timeout in nanoseconds and next as a JSON string
+	memo := `{"forward":{"receiver":"` + receiver + `","port":"transfer","channel":"channel-1","timeout":600000000000,` +
+		`"next":"{\"forward\":{\"receiver\":\"` + receiver + `\",\"port\":\"transfer\",\"channel\":\"channel-2\"}}"}}`
+
+	m, err := ibcmemo.ParseMemo(memo)
+	require.NoError(t, err)
+	require.Equal(t, ibcmemo.Duration(10*time.Minute), m.Forward.Timeout)
+	require.Equal(t, "channel-2", m.Forward.Next.Forward.Channel)
+}
+
+func TestForward_Invalid(t *testing.T) {
+	receiver := osmoAddress(t)
+
+	testCases := []struct {
+		desc    string
+		forward *ibcmemo.ForwardMetadata
+		rule    string
+		msg     string
+	}{
+		{
+			desc:    "empty receiver",
+			forward: ibcmemo.NewForward("", "transfer", "channel-1"),
+			rule:    validators.RuleAddressEmpty,
+		},
+		{
+			desc:    "receiver with bad checksum",
+			forward: ibcmemo.NewForward(receiver[:len(receiver)-1]+"q", "transfer", "channel-1"),
+			rule:    validators.RuleAddressInvalidBech32,
+		},
+		{
+			desc:    "invalid port",
+			forward: ibcmemo.NewForward(receiver, "t", "channel-1"),
+			rule:    validators.RulePortInvalidLength,
+		},
+		{
+			desc:    "invalid channel",
+			forward: ibcmemo.NewForward(receiver, "transfer", "chan"),
+			rule:    validators.RuleChannelInvalidFormat,
+		},
+		{
+			desc:    "invalid nested hop",
+			forward: ibcmemo.NewForward(receiver, "transfer", "channel-1").WithNext(ibcmemo.NewForward(receiver, "transfer", "").Memo()),
+			rule:    validators.RuleChannelEmpty,
+			msg:     "forward hop 2: invalid channel identifier: channel cannot be empty",
+		},
+		{
+			desc:    "negative timeout",
+			forward: ibcmemo.NewForward(receiver, "transfer", "channel-1").WithTimeout(-time.Second),
+			msg:     "forward hop 1: timeout -1s cannot be negative: invalid memo",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tc.forward.Memo().Build()
+			require.Error(t, err)
+
+			if tc.rule != "" {
+				var vErr *validators.ValidationError
+				require.True(t, errors.As(err, &vErr))
+				require.Equal(t, tc.rule, vErr.Rule)
+			}
+			if tc.msg != "" {
+				require.Equal(t, tc.msg, err.Error())
+			}
+		})
+	}
+}
+
+func TestForward_MaxHops(t *testing.T) {
+	receiver := osmoAddress(t)
+
+	build := func(hops int) *ibcmemo.ForwardMetadata {
+		forward := ibcmemo.NewForward(receiver, "transfer", "channel-0")
+		for i := 1; i < hops; i++ {
+			forward = ibcmemo.NewForward(receiver, "transfer", "channel-0").WithNext(forward.Memo())
+		}
+		return forward
+	}
+
+	forward := build(constants.MaxForwardHops)
+	require.Equal(t, constants.MaxForwardHops, forward.Hops())
+	require.NoError(t, forward.Validate())
+
+	err := build(constants.MaxForwardHops + 1).Validate()
+	require.ErrorIs(t, err, transfertypes.ErrInvalidMemo)
+	require.Equal(t, "forward memo: cannot have more than 8 nested forwards: invalid memo", err.Error())
+}
@@ -0,0 +1,216 @@
+// Package ibcmemo builds and parses the JSON memos of ICS-20 transfers understood by the IBC middlewares.
+package ibcmemo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	errorsmod "cosmossdk.io/errors"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+
+	"zigchain/zutils/constants"
+	"zigchain/zutils/validators"
+)
+
+// Memo keys of the entries known to this package.
+const (
+	// ForwardKey is the memo key of the packet-forward-middleware.
+	ForwardKey = "forward"
+	// SrcCallbackKey is the memo key of the IBC callback on the source chain.
+	SrcCallbackKey = "src_callback"
+	// DestCallbackKey is the memo key of the IBC callback on the destination chain.
+	DestCallbackKey = "dest_callback"
+)
+
+// Memo is a JSON object memo of an ICS-20 transfer.
+//
+// The entries known to this package are typed, every other top-level entry (e.g. a wasm hook)
+// is kept in Extra and written back unchanged.
+type Memo struct {
+	// Forward is the packet-forward-middleware entry, {"forward":{...}}.
+	Forward *ForwardMetadata
+	// SrcCallback is the IBC callback entry for the source chain, {"src_callback":{...}}.
+	SrcCallback *CallbackMetadata
+	// DestCallback is the IBC callback entry for the destination chain, {"dest_callback":{...}}.
+	DestCallback *CallbackMetadata
+	// Extra holds the entries not known to this package.
+	Extra map[string]json.RawMessage
+}
+
+// ParseMemo parses and validates the memo of a transfer sent from this chain, see Memo.Validate.
+// An empty memo returns an empty Memo.
+func ParseMemo(memo string) (Memo, error) {
+	return ParseMemoFor(memo, validators.PacketOutgoing)
+}
+
+// ParseMemoFor parses and validates the memo of a packet with the given direction, see Memo.ValidateFor.
+// An empty memo returns an empty Memo.
+func ParseMemoFor(memo string, direction validators.PacketDirection) (Memo, error) {
+	var m Memo
+	if len(bytes.TrimSpace([]byte(memo))) == 0 {
+		return m, nil
+	}
+
+	if err := json.Unmarshal([]byte(memo), &m); err != nil {
+		return Memo{}, errorsmod.Wrapf(transfertypes.ErrInvalidMemo, "memo is not a valid JSON object: %s", err)
+	}
+
+	if err := m.ValidateFor(direction); err != nil {
+		return Memo{}, err
+	}
+
+	return m, nil
+}
+
+// Validate validates every typed entry of the memo of a transfer sent from this chain, the memos built by Build.
+func (m Memo) Validate() error {
+	return m.ValidateFor(validators.PacketOutgoing)
+}
+
+// ValidateFor validates every typed entry of the memo of a packet with the given direction.
+//
+// The callback running on this chain, src_callback of an outgoing and dest_callback of an incoming packet,
+// has to be a zigchain contract (CallbackMetadata.Validate); the other one runs on the counterparty chain
+// (CallbackMetadata.ValidateForeign). The callbacks of the memos nested in a forward run on the chains of
+// the following hops, only the src_callback of the packet forwarded by zigchain runs here.
+func (m Memo) ValidateFor(direction validators.PacketDirection) error {
+	return m.validate(sideOf(direction), 0)
+}
+
+func (m Memo) validate(side packetSide, depth int) error {
+	if err := validateCallbacks(m.SrcCallback, m.DestCallback, side); err != nil {
+		return err
+	}
+	if m.Forward != nil {
+		if err := m.Forward.validate(side, depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithSrcCallback returns a copy of the memo with the source chain callback set.
+func (m Memo) WithSrcCallback(callback *CallbackMetadata) Memo {
+	m.SrcCallback = callback
+	return m
+}
+
+// WithDestCallback returns a copy of the memo with the destination chain callback set.
+func (m Memo) WithDestCallback(callback *CallbackMetadata) Memo {
+	m.DestCallback = callback
+	return m
+}
+
+// IsEmpty reports whether the memo has no entries.
+func (m Memo) IsEmpty() bool {
+	return m.Forward == nil && m.SrcCallback == nil && m.DestCallback == nil && len(m.Extra) == 0
+}
+
+// String returns the JSON encoding of the memo, or an empty string for an empty memo.
+// It does not validate the memo, use Build for that.
+func (m Memo) String() string {
+	if m.IsEmpty() {
+		return ""
+	}
+	bz, err := json.Marshal(m)
+	if err != nil {
+		return ""
+	}
+	return string(bz)
+}
+
+// Build validates the memo and returns its JSON encoding, ready to be set as the transfer memo.
+func (m Memo) Build() (string, error) {
+	if err := m.Validate(); err != nil {
+		return "", err
+	}
+	if m.IsEmpty() {
+		return "", nil
+	}
+
+	bz, err := json.Marshal(m)
+	if err != nil {
+		return "", errorsmod.Wrapf(transfertypes.ErrInvalidMemo, "cannot encode memo: %s", err)
+	}
+	return string(bz), nil
+}
+
+// MarshalJSON implements json.Marshaler, the entries are written in key order.
+func (m Memo) MarshalJSON() ([]byte, error) {
+	entries := make(map[string]interface{}, len(m.Extra)+3)
+	for key, value := range m.Extra {
+		entries[key] = value
+	}
+	if m.Forward != nil {
+		entries[ForwardKey] = m.Forward
+	}
+	if m.SrcCallback != nil {
+		entries[SrcCallbackKey] = m.SrcCallback
+	}
+	if m.DestCallback != nil {
+		entries[DestCallbackKey] = m.DestCallback
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+//
+// Besides a JSON object it accepts a JSON string holding the object, the form of nested
+// memos produced by older versions of the packet-forward-middleware.
+func (m *Memo) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var inner string
+		if err := json.Unmarshal(data, &inner); err != nil {
+			return err
+		}
+		data = []byte(inner)
+	}
+
+	var entries map[string]json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	if entries == nil {
+		return errors.New("memo cannot be null")
+	}
+
+	*m = Memo{}
+	if raw, ok := entries[ForwardKey]; ok {
+		var forward ForwardMetadata
+		if err := json.Unmarshal(raw, &forward); err != nil {
+			return err
+		}
+		m.Forward = &forward
+		delete(entries, ForwardKey)
+	}
+
+	for key, callback := range map[string]**CallbackMetadata{SrcCallbackKey: &m.SrcCallback, DestCallbackKey: &m.DestCallback} {
+		raw, ok := entries[key]
+		if !ok {
+			continue
+		}
+		*callback = new(CallbackMetadata)
+		if err := json.Unmarshal(raw, *callback); err != nil {
+			return err
+		}
+		delete(entries, key)
+	}
+
+	if len(entries) > 0 {
+		m.Extra = entries
+	}
+
+	return nil
+}
+
+// checkMemoAddress validates the receiver of a forward, which can be an address of this or of another chain.
+// zigchain addresses pass validators.AddressCheck, other addresses validators.ForeignAddressCheck.
+func checkMemoAddress(field string, addr string) error {
+	if hrp, _, err := bech32.DecodeAndConvert(addr); err == nil && hrp == constants.AddressPrefix {
+		return validators.AddressCheck(field, addr)
+	}
+	return validators.ForeignAddressCheck(field, addr)
+}
@@ -0,0 +1,48 @@
+package ibcmemo_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/ibcmemo"
+)
+
+func TestParseMemo_Empty(t *testing.T) {
+	for _, memo := range []string{"", "  "} {
+		m, err := ibcmemo.ParseMemo(memo)
+		require.NoError(t, err)
+		require.True(t, m.IsEmpty())
+		require.Equal(t, "", m.String())
+	}
+}
+
+func TestParseMemo_KeepsUnknownEntries(t *testing.T) {
+	memo := `{"wasm":{"contract":"zig1xyz","msg":{}},"other":1}`
+
+	m, err := ibcmemo.ParseMemo(memo)
+	require.NoError(t, err)
+	require.Nil(t, m.Forward)
+	require.Len(t, m.Extra, 2)
+	require.JSONEq(t, memo, m.String())
+}
+
+func TestParseMemo_Invalid(t *testing.T) {
+	for _, memo := range []string{"plain text", "[]", "null", `{"forward":`, `{"forward":"x"}`} {
+		_, err := ibcmemo.ParseMemo(memo)
+		require.ErrorIs(t, err, transfertypes.ErrInvalidMemo, memo)
+	}
+}
+
+func TestMemo_Build(t *testing.T) {
+	built, err := ibcmemo.Memo{}.Build()
+	require.NoError(t, err)
+	require.Equal(t, "", built)
+
+	m := ibcmemo.Memo{Extra: map[string]json.RawMessage{"b": json.RawMessage(`2`), "a": json.RawMessage(`1`)}}
+	built, err = m.Build()
+	require.NoError(t, err)
+	require.Equal(t, `{"a":1,"b":2}`, built, "entries are written in key order")
+}
@@ -1,12 +1,6 @@
 package validators
 
 import (
-	"strings"
-
-	"zigchain/zutils/constants"
-
-	errorsmod "cosmossdk.io/errors"
-	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 )
 
@@ -14,8 +8,12 @@ import (
 //
 // It performs the following checks:
 // 1. Ensures the address is not empty.
-// 2. Verifies that the address is a valid Bech32 address.
-// 3. Checks if the address has the correct prefix as defined in constants.AddressPrefix.
+// 2. Verifies that the address is a valid Bech32 address (charset and checksum).
+// 3. Checks that the decoded prefix is exactly constants.AddressPrefix.
+// 4. Checks that the address bytes are not empty and not longer than address.MaxAddrLen.
+//
+// The checks are the ones of Bech32AddressCheck with AddressRoleAccount, so they do not depend on the global
+// SDK bech32 configuration; only the messages differ, they keep the wording of sdk.AccAddressFromBech32.
 //
 // Parameters:
 //   - field: A string representing the name of the field being validated. This is used in error messages.
@@ -26,37 +24,20 @@ import (
 
 func SignerCheck(signer string) error {
 
-	if signer == "" {
-		return errorsmod.Wrapf(
-			sdkerrors.ErrInvalidAddress,
-			"SIGNER ADDRESS: '%s' (empty address string is not allowed)", signer,
-		)
-	}
-
-	_, err := sdk.AccAddressFromBech32(signer)
-	if err != nil {
-		return errorsmod.Wrapf(
-			sdkerrors.ErrInvalidAddress,
-			"SIGNER ADDRESS: '%s' (%s)", signer, err,
-		)
-	}
-
-	if !strings.HasPrefix(signer, constants.AddressPrefix) {
-		return errorsmod.Wrapf(
-			sdkerrors.ErrInvalidAddress,
-			"SIGNER ADDRESS: invalid prefix: expected '%s', got '%s'", constants.AddressPrefix, signer[:len(constants.AddressPrefix)],
-		)
-	}
-
-	return nil
+	_, err := decodeBech32Address(FieldSigner, signer, AddressRoleAccount)
+	return sdkAddressError(err, "SIGNER ADDRESS")
 }
 
 // AddressCheck validates the given address string.
 //
 // It performs the following checks:
 // 1. Ensures the address is not empty.
-// 2. Verifies that the address is a valid Bech32 address.
-// 3. Checks if the address has the correct prefix as defined in constants.AddressPrefix.
+// 2. Verifies that the address is a valid Bech32 address (charset and checksum).
+// 3. Checks that the decoded prefix is exactly constants.AddressPrefix.
+// 4. Checks that the address bytes are not empty and not longer than address.MaxAddrLen.
+//
+// The checks are the ones of Bech32AddressCheck with AddressRoleAccount, so they do not depend on the global
+// SDK bech32 configuration; only the messages differ, they keep the wording of sdk.AccAddressFromBech32.
 //
 // Parameters:
 //   - field: A string representing the name of the field being validated. This is used in error messages.
@@ -67,34 +48,16 @@ func SignerCheck(signer string) error {
 func AddressCheck(field string, address string) error {
 
 	if address == "" {
-		return errorsmod.Wrapf(
-			sdkerrors.ErrInvalidAddress,
-			"%s address: cannot be empty",
-			field,
-		)
-	}
-
-	_, err := sdk.AccAddressFromBech32(address)
-	if err != nil {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidAddress,
-			"%s address: '%s' (%s)",
 			field,
+			RuleAddressEmpty,
 			address,
-			err,
-		)
-	}
-
-	if !strings.HasPrefix(address, constants.AddressPrefix) {
-		return errorsmod.Wrapf(
-			sdkerrors.ErrInvalidAddress,
-			"%s address: '%s' has invalid prefix: expected '%s', got '%s'",
+			"%s address: cannot be empty",
 			field,
-			address,
-			constants.AddressPrefix,
-			address[:len(constants.AddressPrefix)],
 		)
 	}
 
-	return nil
+	_, err := decodeBech32Address(field, address, AddressRoleAccount)
+	return sdkAddressError(err, field+" address")
 }
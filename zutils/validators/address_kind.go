@@ -0,0 +1,99 @@
+package validators
+
+import (
+	"fmt"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// UserAddressLength is the length of addresses derived from a public key.
+	UserAddressLength = 20
+	// ContractAddressLength is the length of CosmWasm contract and derived module account addresses.
+	ContractAddressLength = 32
+)
+
+// AddressKind tells what kind of account an address belongs to, based on its length.
+type AddressKind int
+
+const (
+	// AddressKindUnknown is an address of any other length.
+	AddressKindUnknown AddressKind = iota
+	// AddressKindUser is a 20-byte address of an account controlled by a key.
+	AddressKindUser
+	// AddressKindContract is a 32-byte address of a CosmWasm contract or a derived module account.
+	AddressKindContract
+)
+
+// String returns a human-readable name of the kind.
+func (k AddressKind) String() string {
+	switch k {
+	case AddressKindUnknown:
+		return "unknown"
+	case AddressKindUser:
+		return "user"
+	case AddressKindContract:
+		return "contract"
+	default:
+		return fmt.Sprintf("AddressKind(%d)", int(k))
+	}
+}
+
+// AddressKindFromBytes returns the kind of the raw address bytes.
+func AddressKindFromBytes(bz []byte) AddressKind {
+	switch len(bz) {
+	case UserAddressLength:
+		return AddressKindUser
+	case ContractAddressLength:
+		return AddressKindContract
+	default:
+		return AddressKindUnknown
+	}
+}
+
+// GetAddressKind validates an account address with Bech32AddressCheck and returns its kind.
+func GetAddressKind(field string, addr string) (AddressKind, error) {
+	bz, err := decodeBech32Address(field, addr, AddressRoleAccount)
+	if err != nil {
+		return AddressKindUnknown, err
+	}
+	return AddressKindFromBytes(bz), nil
+}
+
+// UserAddressCheck validates an account address and checks that it is a 20-byte user account.
+//
+// Use it where the address has to be able to sign, e.g. a recipient of tokens that
+// a contract would not know how to handle.
+func UserAddressCheck(field string, addr string) error {
+	return addressKindCheck(field, addr, AddressKindUser, RuleAddressNotUser, UserAddressLength)
+}
+
+// ContractAddressCheck validates an account address and checks that it is a 32-byte contract address.
+func ContractAddressCheck(field string, addr string) error {
+	return addressKindCheck(field, addr, AddressKindContract, RuleAddressNotContract, ContractAddressLength)
+}
+
+// addressKindCheck validates an account address and checks that it has the expected kind.
+func addressKindCheck(field string, addr string, expected AddressKind, rule string, length int) error {
+	bz, err := decodeBech32Address(field, addr, AddressRoleAccount)
+	if err != nil {
+		return err
+	}
+
+	if AddressKindFromBytes(bz) != expected {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			field,
+			rule,
+			addr,
+			"%s address: '%s' is %d bytes long, expected a %s address of %d bytes",
+			field,
+			addr,
+			len(bz),
+			expected,
+			length,
+		).withLimits(length, length)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package validators_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/testutil/sample"
+	"zigchain/zutils/validators"
+)
+
+func TestGetAddressKind(t *testing.T) {
+	testCases := []struct {
+		desc string
+		addr string
+		kind validators.AddressKind
+	}{
+		{desc: "user", addr: sample.AccAddress(), kind: validators.AddressKindUser},
+		{desc: "contract", addr: mustBech32(t, "zig", bytes.Repeat([]byte{0x01}, 32)), kind: validators.AddressKindContract},
+		{desc: "other length", addr: mustBech32(t, "zig", bytes.Repeat([]byte{0x01}, 24)), kind: validators.AddressKindUnknown},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			kind, err := validators.GetAddressKind("to", tc.addr)
+			require.NoError(t, err)
+			require.Equal(t, tc.kind, kind)
+		})
+	}
+
+	_, err := validators.GetAddressKind("to", mustBech32(t, "cosmos", bytes.Repeat([]byte{0x01}, 20)))
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidAddress)
+}
+
+func TestAddressKind_String(t *testing.T) {
+	require.Equal(t, "user", validators.AddressKindUser.String())
+	require.Equal(t, "contract", validators.AddressKindContract.String())
+	require.Equal(t, "unknown", validators.AddressKindUnknown.String())
+	require.Equal(t, "AddressKind(7)", validators.AddressKind(7).String())
+}
+
+func TestUserAddressCheck(t *testing.T) {
+	require.NoError(t, validators.UserAddressCheck("recipient", sample.AccAddress()))
+
+	contract := mustBech32(t, "zig", bytes.Repeat([]byte{0x01}, 32))
+	err := validators.UserAddressCheck("recipient", contract)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidAddress)
+	require.Equal(
+		t,
+		"recipient address: '"+contract+"' is 32 bytes long, expected a user address of 20 bytes: invalid address",
+		err.Error(),
+	)
+
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleAddressNotUser, vErr.Rule)
+	require.Equal(t, validators.UserAddressLength, vErr.Min)
+	require.Equal(t, validators.UserAddressLength, vErr.Max)
+
+	// the usual address checks run first
+	err = validators.UserAddressCheck("recipient", "")
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleAddressEmpty, vErr.Rule)
+}
+
+func TestContractAddressCheck(t *testing.T) {
+	require.NoError(t, validators.ContractAddressCheck("contract", mustBech32(t, "zig", bytes.Repeat([]byte{0x01}, 32))))
+
+	err := validators.ContractAddressCheck("contract", sample.AccAddress())
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidAddress)
+
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleAddressNotContract, vErr.Rule)
+
+	err = validators.ContractAddressCheck("contract", mustBech32(t, "zig", bytes.Repeat([]byte{0x01}, 24)))
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleAddressNotContract, vErr.Rule)
+}
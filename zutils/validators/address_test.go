@@ -1,10 +1,13 @@
 package validators_test
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 	"github.com/stretchr/testify/require"
 
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -160,3 +163,63 @@ func TestAddressCheckInvalidPrefix(t *testing.T) {
 		err.Error(),
 	)
 }
+
+func TestAddressCheckPrefixIsDecoded(t *testing.T) {
+	// Test case: the prefix check compares the whole decoded prefix, not the first characters
+
+	field := "testField"
+	accAddr := sdk.AccAddress([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0A, 0x0B, 0x0C, 0x0D, 0x0E, 0x0F, 0x10, 0x11, 0x12, 0x13, 0x14})
+
+	for _, prefix := range []string{"zigvaloper", "zigpub", "zi", "cosmos"} {
+		addr, err := sdk.Bech32ifyAddressBytes(prefix, accAddr)
+		require.NoError(t, err)
+
+		err = validators.AddressCheck(field, addr)
+		require.ErrorIs(t, err, sdkerrors.ErrInvalidAddress)
+		require.Equal(
+			t,
+			fmt.Sprintf(
+				"%s address: '%s' (invalid Bech32 prefix; expected %s, got %s): invalid address",
+				field,
+				addr,
+				constants.AddressPrefix,
+				prefix,
+			),
+			err.Error(),
+		)
+
+		var vErr *validators.ValidationError
+		require.True(t, errors.As(err, &vErr))
+		require.Equal(t, validators.RuleAddressInvalidPrefix, vErr.Rule)
+	}
+}
+
+func TestAddressCheckUppercase(t *testing.T) {
+	// Test case: bech32 allows an all uppercase address
+
+	require.NoError(t, validators.AddressCheck("testField", strings.ToUpper(sample.AccAddress())))
+}
+
+func TestAddressCheckByteLength(t *testing.T) {
+	// Test case: the decoded address bytes are checked
+
+	field := "testField"
+
+	noBytes, err := bech32.ConvertAndEncode(constants.AddressPrefix, nil)
+	require.NoError(t, err)
+
+	err = validators.AddressCheck(field, noBytes)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidAddress)
+	require.Equal(t, fmt.Sprintf("%s address: '%s' (addresses cannot be empty): invalid address", field, noBytes), err.Error())
+
+	tooLong, err := bech32.ConvertAndEncode(constants.AddressPrefix, make([]byte, 256))
+	require.NoError(t, err)
+
+	err = validators.SignerCheck(tooLong)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidAddress)
+	require.Equal(t, fmt.Sprintf("SIGNER ADDRESS: '%s' (address max length is 255, got 256): invalid address", tooLong), err.Error())
+
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleAddressTooLong, vErr.Rule)
+}
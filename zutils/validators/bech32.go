@@ -0,0 +1,254 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/address"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"zigchain/zutils/constants"
+)
+
+// AddressRole is the role of a bech32 string, each role has its own prefix.
+type AddressRole int
+
+const (
+	// AddressRoleAccount is an account address, e.g. zig1...
+	AddressRoleAccount AddressRole = iota
+	// AddressRoleAccountPubKey is an account public key, e.g. zigpub1...
+	AddressRoleAccountPubKey
+	// AddressRoleValidator is a validator operator address, e.g. zigvaloper1...
+	AddressRoleValidator
+	// AddressRoleConsensus is a validator consensus address, e.g. zigvalcons1...
+	AddressRoleConsensus
+)
+
+// Prefix returns the bech32 prefix of the role.
+func (r AddressRole) Prefix() string {
+	switch r {
+	case AddressRoleAccount:
+		return constants.AddressPrefix
+	case AddressRoleAccountPubKey:
+		return constants.AccountPubKeyPrefix
+	case AddressRoleValidator:
+		return constants.ValidatorAddressPrefix
+	case AddressRoleConsensus:
+		return constants.ConsensusAddressPrefix
+	default:
+		return ""
+	}
+}
+
+// String returns a human-readable name of the role.
+func (r AddressRole) String() string {
+	switch r {
+	case AddressRoleAccount:
+		return "account"
+	case AddressRoleAccountPubKey:
+		return "account public key"
+	case AddressRoleValidator:
+		return "validator"
+	case AddressRoleConsensus:
+		return "consensus"
+	default:
+		return fmt.Sprintf("AddressRole(%d)", int(r))
+	}
+}
+
+// Bech32AddressCheck validates a bech32 string of the given role.
+//
+// Like AddressCheck it does not depend on the global SDK bech32 configuration.
+//
+// It performs the following checks:
+// 1. Ensures the address is not empty.
+// 2. Verifies that the address is valid bech32 (charset and checksum).
+// 3. Checks that the prefix is exactly the one of the role, e.g. zigvaloper for AddressRoleValidator.
+// 4. Checks that the decoded bytes are not empty and not longer than address.MaxAddrLen.
+//
+// Parameters:
+//   - field: the name of the field being validated, used in error messages.
+//   - addr: the bech32 string to validate.
+//   - role: the expected role of the address.
+//
+// Returns:
+//   - error: nil if the address is valid, otherwise a ValidationError.
+func Bech32AddressCheck(field string, addr string, role AddressRole) error {
+	_, err := decodeBech32Address(field, addr, role)
+	return err
+}
+
+// decodeBech32Address performs the checks of Bech32AddressCheck and returns the address bytes.
+//
+// Every error carries the reason in the words of sdk.AccAddressFromBech32 as its cause,
+// see sdkAddressError.
+func decodeBech32Address(field string, addr string, role AddressRole) ([]byte, error) {
+	if addr == "" {
+		return nil, wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			field,
+			RuleAddressEmpty,
+			addr,
+			"%s address: cannot be empty",
+			field,
+		).withCause(errors.New("empty address string is not allowed"))
+	}
+
+	hrp, bz, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return nil, wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			field,
+			RuleAddressInvalidBech32,
+			addr,
+			"%s address: '%s' (%s)",
+			field,
+			addr,
+			err,
+		).withCause(err)
+	}
+
+	if hrp != role.Prefix() {
+		return nil, wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			field,
+			RuleAddressInvalidPrefix,
+			addr,
+			"%s address: '%s' has invalid prefix: expected '%s', got '%s'",
+			field,
+			addr,
+			role.Prefix(),
+			hrp,
+		).withCause(fmt.Errorf("invalid Bech32 prefix; expected %s, got %s", role.Prefix(), hrp))
+	}
+
+	if len(bz) == 0 {
+		return nil, wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			field,
+			RuleAddressEmptyBytes,
+			addr,
+			"%s address: '%s' has no address bytes",
+			field,
+			addr,
+		).withCause(errors.New("addresses cannot be empty"))
+	}
+
+	if len(bz) > address.MaxAddrLen {
+		return nil, wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			field,
+			RuleAddressTooLong,
+			addr,
+			"%s address: '%s' is %d bytes long, max is %d",
+			field,
+			addr,
+			len(bz),
+			address.MaxAddrLen,
+		).withLimits(1, address.MaxAddrLen).withCause(fmt.Errorf("address max length is %d, got %d", address.MaxAddrLen, len(bz)))
+	}
+
+	return bz, nil
+}
+
+// sdkAddressError rewrites an error of decodeBech32Address as "<label>: '<address>' (<cause>)",
+// the message of sdk.AccAddressFromBech32 that AddressCheck and SignerCheck have always returned.
+// The field, rule and limits are kept.
+func sdkAddressError(err error, label string) error {
+	var vErr *ValidationError
+	if !errors.As(err, &vErr) || vErr.cause == nil {
+		return err
+	}
+
+	return wrapValidationError(
+		vErr.Code,
+		vErr.Field,
+		vErr.Rule,
+		vErr.Value,
+		"%s: '%s' (%s)",
+		label,
+		vErr.Value,
+		vErr.cause,
+	).withLimits(vErr.Min, vErr.Max)
+}
+
+// ValidatorAddressCheck validates a validator operator address, e.g. zigvaloper1...
+func ValidatorAddressCheck(field string, addr string) error {
+	return Bech32AddressCheck(field, addr, AddressRoleValidator)
+}
+
+// ConsensusAddressCheck validates a validator consensus address, e.g. zigvalcons1...
+func ConsensusAddressCheck(field string, addr string) error {
+	return Bech32AddressCheck(field, addr, AddressRoleConsensus)
+}
+
+// AccountPubKeyCheck validates a bech32 encoded account public key, e.g. zigpub1...
+func AccountPubKeyCheck(field string, pubKey string) error {
+	return Bech32AddressCheck(field, pubKey, AddressRoleAccountPubKey)
+}
+
+// ConvertBech32Prefix re-encodes a bech32 address with another prefix, keeping the address bytes.
+//
+// It is meant for tooling that maps addresses between chains, e.g. cosmos1... or osmo1... to zig1...
+// The input can have any prefix; the output is always lowercase.
+//
+// Parameters:
+//   - addr: the bech32 address to convert.
+//   - prefix: the prefix of the converted address, e.g. constants.AddressPrefix.
+//
+// Returns:
+//   - converted: the address with the new prefix.
+//   - originalPrefix: the prefix addr was encoded with.
+//   - error: if addr is not valid bech32 or the prefix is empty.
+func ConvertBech32Prefix(addr string, prefix string) (converted string, originalPrefix string, err error) {
+	if addr == "" {
+		return "", "", wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			FieldAddress,
+			RuleAddressEmpty,
+			addr,
+			"address: cannot be empty",
+		)
+	}
+
+	originalPrefix, bz, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return "", "", wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			FieldAddress,
+			RuleAddressInvalidBech32,
+			addr,
+			"address: '%s' (%s)",
+			addr,
+			err,
+		)
+	}
+
+	if prefix == "" {
+		return "", "", wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			FieldAddress,
+			RuleAddressInvalidPrefix,
+			prefix,
+			"address: cannot convert '%s' to an empty prefix",
+			addr,
+		)
+	}
+
+	converted, err = bech32.ConvertAndEncode(prefix, bz)
+	if err != nil {
+		return "", "", wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			FieldAddress,
+			RuleAddressInvalidPrefix,
+			prefix,
+			"address: cannot convert '%s' to prefix '%s' (%s)",
+			addr,
+			prefix,
+			err,
+		)
+	}
+
+	return converted, originalPrefix, nil
+}
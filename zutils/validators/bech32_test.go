@@ -0,0 +1,125 @@
+package validators_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/constants"
+	"zigchain/zutils/validators"
+)
+
+func mustBech32(t *testing.T, prefix string, bz []byte) string {
+	t.Helper()
+
+	addr, err := bech32.ConvertAndEncode(prefix, bz)
+	require.NoError(t, err)
+	return addr
+}
+
+func TestAddressRole_Prefix(t *testing.T) {
+	require.Equal(t, "zig", validators.AddressRoleAccount.Prefix())
+	require.Equal(t, "zigpub", validators.AddressRoleAccountPubKey.Prefix())
+	require.Equal(t, "zigvaloper", validators.AddressRoleValidator.Prefix())
+	require.Equal(t, "zigvalcons", validators.AddressRoleConsensus.Prefix())
+	require.Equal(t, "", validators.AddressRole(99).Prefix())
+	require.Equal(t, "AddressRole(99)", validators.AddressRole(99).String())
+}
+
+func TestBech32AddressCheck_Valid(t *testing.T) {
+	bz := bytes.Repeat([]byte{0x01}, 20)
+
+	require.NoError(t, validators.Bech32AddressCheck("to", mustBech32(t, "zig", bz), validators.AddressRoleAccount))
+	require.NoError(t, validators.ValidatorAddressCheck("validator", mustBech32(t, "zigvaloper", bz)))
+	require.NoError(t, validators.ConsensusAddressCheck("consensus", mustBech32(t, "zigvalcons", bz)))
+	require.NoError(t, validators.AccountPubKeyCheck("pubkey", mustBech32(t, "zigpub", bytes.Repeat([]byte{0x02}, 38))))
+
+	// 32-byte contract addresses are accounts too
+	require.NoError(t, validators.Bech32AddressCheck("to", mustBech32(t, "zig", bytes.Repeat([]byte{0x03}, 32)), validators.AddressRoleAccount))
+}
+
+func TestBech32AddressCheck_Invalid(t *testing.T) {
+	bz := bytes.Repeat([]byte{0x01}, 20)
+	valoper := mustBech32(t, "zigvaloper", bz)
+
+	testCases := []struct {
+		desc string
+		addr string
+		role validators.AddressRole
+		rule string
+	}{
+		{desc: "empty", addr: "", role: validators.AddressRoleValidator, rule: validators.RuleAddressEmpty},
+		{desc: "bad checksum", addr: valoper[:len(valoper)-1] + "q", role: validators.AddressRoleValidator, rule: validators.RuleAddressInvalidBech32},
+		{desc: "account as validator", addr: mustBech32(t, "zig", bz), role: validators.AddressRoleValidator, rule: validators.RuleAddressInvalidPrefix},
+		{desc: "validator as account", addr: valoper, role: validators.AddressRoleAccount, rule: validators.RuleAddressInvalidPrefix},
+		{desc: "other chain", addr: mustBech32(t, "cosmos", bz), role: validators.AddressRoleAccount, rule: validators.RuleAddressInvalidPrefix},
+		{desc: "no bytes", addr: mustBech32(t, "zigvaloper", nil), role: validators.AddressRoleValidator, rule: validators.RuleAddressEmptyBytes},
+		{desc: "too long", addr: mustBech32(t, "zig", bytes.Repeat([]byte{0x01}, 256)), role: validators.AddressRoleAccount, rule: validators.RuleAddressTooLong},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := validators.Bech32AddressCheck("field", tc.addr, tc.role)
+			require.ErrorIs(t, err, sdkerrors.ErrInvalidAddress)
+
+			var vErr *validators.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, tc.rule, vErr.Rule)
+			require.Equal(t, "field", vErr.Field)
+		})
+	}
+}
+
+func TestBech32AddressCheck_PrefixMessage(t *testing.T) {
+	addr := mustBech32(t, "zig", bytes.Repeat([]byte{0x01}, 20))
+
+	err := validators.ValidatorAddressCheck("validator", addr)
+	require.Equal(
+		t,
+		"validator address: '"+addr+"' has invalid prefix: expected 'zigvaloper', got 'zig': invalid address",
+		err.Error(),
+	)
+}
+
+func TestConvertBech32Prefix(t *testing.T) {
+	bz := bytes.Repeat([]byte{0xab}, 20)
+	zigAddr := mustBech32(t, constants.AddressPrefix, bz)
+
+	for _, prefix := range []string{"cosmos", "osmo", "zigvaloper"} {
+		t.Run(prefix, func(t *testing.T) {
+			converted, original, err := validators.ConvertBech32Prefix(mustBech32(t, prefix, bz), constants.AddressPrefix)
+			require.NoError(t, err)
+			require.Equal(t, zigAddr, converted)
+			require.Equal(t, prefix, original)
+		})
+	}
+
+	// uppercase input is accepted, the output is lowercase
+	converted, original, err := validators.ConvertBech32Prefix(strings.ToUpper(mustBech32(t, "osmo", bz)), constants.AddressPrefix)
+	require.NoError(t, err)
+	require.Equal(t, zigAddr, converted)
+	require.Equal(t, "osmo", original)
+
+	// and back again
+	converted, _, err = validators.ConvertBech32Prefix(zigAddr, "cosmos")
+	require.NoError(t, err)
+	require.Equal(t, mustBech32(t, "cosmos", bz), converted)
+}
+
+func TestConvertBech32Prefix_Invalid(t *testing.T) {
+	zigAddr := mustBech32(t, constants.AddressPrefix, bytes.Repeat([]byte{0xab}, 20))
+
+	_, _, err := validators.ConvertBech32Prefix("", constants.AddressPrefix)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidAddress)
+
+	_, _, err = validators.ConvertBech32Prefix("not-an-address", constants.AddressPrefix)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidAddress)
+
+	_, _, err = validators.ConvertBech32Prefix(zigAddr, "")
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidAddress)
+}
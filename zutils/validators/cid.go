@@ -0,0 +1,309 @@
+package validators
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Multihash and CID codec codes of the multiformats table used by IPFS.
+const (
+	MultihashIdentity = 0x00
+	MultihashSHA2256  = 0x12
+	MultihashSHA2512  = 0x13
+
+	CIDCodecRaw     = 0x55
+	CIDCodecDagPB   = 0x70
+	CIDCodecDagCBOR = 0x71
+	CIDCodecDagJSON = 0x0129
+)
+
+// Content-addressed URI schemes, see ParseContentURI.
+const (
+	IPFSScheme    = "ipfs"
+	ArweaveScheme = "ar"
+)
+
+// maxMultihashDigestLength bounds the digest of a multihash, the longest common hash functions produce 64 bytes.
+const maxMultihashDigestLength = 128
+
+// cidV0Length is the length of a CIDv0, the base58 encoding of a SHA-256 multihash.
+const cidV0Length = 46
+
+// maxCIDLength bounds a CID before it is decoded, as base58 decoding is quadratic in the input length.
+// It fits a CIDv1 with the longest accepted digest in base16, the least compact multibase.
+const maxCIDLength = 1 + 2*(16+maxMultihashDigestLength)
+
+// base58Alphabet is the bitcoin base58 alphabet used by CIDv0 and the 'z' multibase.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Multihash is a self-describing hash: the code of the hash function followed by the digest.
+type Multihash struct {
+	Code   uint64
+	Digest []byte
+}
+
+// IsSHA256 reports whether the multihash is a SHA-256 digest.
+func (m Multihash) IsSHA256() bool {
+	return m.Code == MultihashSHA2256 && len(m.Digest) == sha256.Size
+}
+
+// CID is an IPFS content identifier.
+//
+// A CIDv0 is the base58 encoding of a SHA-256 multihash of a dag-pb block, e.g. Qm...
+// A CIDv1 is a multibase string (e.g. bafy... in base32) of the version, the content codec and the multihash.
+type CID struct {
+	Version   uint64
+	Codec     uint64
+	Multihash Multihash
+}
+
+// ParseCID parses a CIDv0 or a CIDv1 in one of the base16, base32, base58btc or base64 multibases.
+//
+// Returns:
+//   - CID: the parsed CID, a CIDv0 has Codec CIDCodecDagPB.
+//   - error: nil if the CID is well-formed, otherwise a ValidationError with rule RuleCIDInvalid.
+func ParseCID(cid string) (CID, error) {
+	if len(cid) > maxCIDLength {
+		return CID{}, invalidCIDError(cid, "too long, max is %d characters", maxCIDLength)
+	}
+
+	if len(cid) == cidV0Length && strings.HasPrefix(cid, "Qm") {
+		bz, err := decodeBase58(cid)
+		if err != nil {
+			return CID{}, invalidCIDError(cid, "CIDv0 is not valid base58 (%s)", err)
+		}
+		mh, err := parseMultihash(bz)
+		if err != nil {
+			return CID{}, invalidCIDError(cid, "%s", err)
+		}
+		if !mh.IsSHA256() {
+			return CID{}, invalidCIDError(cid, "CIDv0 has to hold a SHA-256 multihash")
+		}
+		return CID{Version: 0, Codec: CIDCodecDagPB, Multihash: mh}, nil
+	}
+
+	bz, err := decodeMultibase(cid)
+	if err != nil {
+		return CID{}, invalidCIDError(cid, "%s", err)
+	}
+
+	version, n := binary.Uvarint(bz)
+	if n <= 0 || version != 1 {
+		return CID{}, invalidCIDError(cid, "unsupported CID version")
+	}
+	bz = bz[n:]
+
+	codec, n := binary.Uvarint(bz)
+	if n <= 0 {
+		return CID{}, invalidCIDError(cid, "missing content codec")
+	}
+
+	mh, err := parseMultihash(bz[n:])
+	if err != nil {
+		return CID{}, invalidCIDError(cid, "%s", err)
+	}
+
+	return CID{Version: 1, Codec: codec, Multihash: mh}, nil
+}
+
+// VerifyDigest checks that uriHash, a SHA-256 hash in any encoding accepted by DetectHashEncoding,
+// is the digest of the CID multihash.
+//
+// The digest is the one of the block the CID points to: for raw CIDs it is the SHA-256 of the file itself,
+// for dag-pb CIDs (e.g. the Qm... and bafy... of files added with default settings) it is the one of the root block.
+func (c CID) VerifyDigest(uriHash string) error {
+	if !c.Multihash.IsSHA256() {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldURIHash,
+			RuleURIHashCIDMismatch,
+			uriHash,
+			"uri hash: CID multihash 0x%x is not SHA-256 and cannot be compared",
+			c.Multihash.Code,
+		)
+	}
+
+	_, digest, err := DetectHashEncoding(uriHash)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(digest[:], c.Multihash.Digest) {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldURIHash,
+			RuleURIHashCIDMismatch,
+			uriHash,
+			"uri hash: '%s' does not match the CID digest %x",
+			uriHash,
+			c.Multihash.Digest,
+		)
+	}
+
+	return nil
+}
+
+// ContentURI is a content-addressed URI: ipfs://{cid}[/path] or ar://{transaction id}[/path].
+type ContentURI struct {
+	// Scheme is IPFSScheme or ArweaveScheme.
+	Scheme string
+	// ID is the CID or the Arweave transaction ID, as written in the URI.
+	ID string
+	// Path is the path inside the content, without the leading '/'.
+	Path string
+	// CID is the parsed ID of an ipfs URI, the zero value for other schemes.
+	CID CID
+}
+
+// IsContentURI reports whether the URI has a content-addressed scheme, without validating it.
+func IsContentURI(uri string) bool {
+	scheme, _, found := strings.Cut(uri, "://")
+	return found && (strings.EqualFold(scheme, IPFSScheme) || strings.EqualFold(scheme, ArweaveScheme))
+}
+
+// ParseContentURI parses an ipfs:// or ar:// URI.
+//
+// It performs the following checks:
+// 1. The scheme is ipfs or ar (case-insensitive).
+// 2. An ipfs ID passes ParseCID.
+// 3. An ar ID is an Arweave transaction ID: 43 characters of unpadded base64url encoding 32 bytes.
+func ParseContentURI(uri string) (ContentURI, error) {
+	scheme, rest, found := strings.Cut(uri, "://")
+	scheme = strings.ToLower(scheme)
+	if !found || (scheme != IPFSScheme && scheme != ArweaveScheme) {
+		return ContentURI{}, wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldURI,
+			RuleURISchemeNotAllowed,
+			uri,
+			"content uri: '%s' has to start with %s:// or %s://",
+			uri,
+			IPFSScheme,
+			ArweaveScheme,
+		)
+	}
+
+	id, path, _ := strings.Cut(rest, "/")
+	parsed := ContentURI{Scheme: scheme, ID: id, Path: path}
+
+	if scheme == IPFSScheme {
+		cid, err := ParseCID(id)
+		if err != nil {
+			return ContentURI{}, withField(err, FieldURI)
+		}
+		parsed.CID = cid
+		return parsed, nil
+	}
+
+	if bz, err := base64.RawURLEncoding.Strict().DecodeString(id); err != nil || len(bz) != sha256.Size {
+		return ContentURI{}, wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldURI,
+			RuleURIInvalidArweaveID,
+			uri,
+			"content uri: '%s' is not an Arweave transaction ID, 43 characters of base64url are expected",
+			id,
+		)
+	}
+
+	return parsed, nil
+}
+
+// invalidCIDError returns the ValidationError of a malformed CID.
+func invalidCIDError(cid string, format string, args ...interface{}) *ValidationError {
+	return wrapValidationError(
+		sdkerrors.ErrInvalidRequest,
+		FieldCID,
+		RuleCIDInvalid,
+		cid,
+		"invalid CID '%s': "+format,
+		append([]interface{}{cid}, args...)...,
+	)
+}
+
+// parseMultihash parses a binary multihash: varint code, varint digest length, digest.
+func parseMultihash(bz []byte) (Multihash, error) {
+	code, n := binary.Uvarint(bz)
+	if n <= 0 {
+		return Multihash{}, fmt.Errorf("missing multihash code")
+	}
+	bz = bz[n:]
+
+	length, n := binary.Uvarint(bz)
+	if n <= 0 || length > maxMultihashDigestLength {
+		return Multihash{}, fmt.Errorf("invalid multihash digest length")
+	}
+	bz = bz[n:]
+
+	if uint64(len(bz)) != length {
+		return Multihash{}, fmt.Errorf("multihash digest is %d bytes long, %d declared", len(bz), length)
+	}
+	if code == MultihashSHA2256 && length != sha256.Size {
+		return Multihash{}, fmt.Errorf("SHA-256 multihash digest has to be %d bytes long", sha256.Size)
+	}
+
+	return Multihash{Code: code, Digest: bz}, nil
+}
+
+// decodeMultibase decodes a multibase string, the first character tells the encoding.
+func decodeMultibase(s string) ([]byte, error) {
+	if len(s) < 2 {
+		return nil, fmt.Errorf("too short")
+	}
+
+	data := s[1:]
+	var (
+		bz  []byte
+		err error
+	)
+	switch s[0] {
+	case 'b':
+		if strings.ToLower(data) != data {
+			return nil, fmt.Errorf("base32 multibase 'b' has to be lowercase")
+		}
+		bz, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(data))
+	case 'B':
+		bz, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(data)
+	case 'f', 'F':
+		bz, err = hex.DecodeString(data)
+	case 'z':
+		bz, err = decodeBase58(data)
+	case 'm':
+		bz, err = base64.RawStdEncoding.Strict().DecodeString(data)
+	case 'u':
+		bz, err = base64.RawURLEncoding.Strict().DecodeString(data)
+	default:
+		return nil, fmt.Errorf("unsupported multibase prefix '%c'", s[0])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid multibase '%c' encoding (%s)", s[0], err)
+	}
+
+	return bz, nil
+}
+
+// decodeBase58 decodes a bitcoin base58 string, every leading '1' is a leading zero byte.
+func decodeBase58(s string) ([]byte, error) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for i, c := range s {
+		idx := strings.IndexRune(base58Alphabet, c)
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid character '%c' at position %d", c, i)
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+
+	zeros := len(s) - len(strings.TrimLeft(s, "1"))
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
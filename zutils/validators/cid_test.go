@@ -0,0 +1,201 @@
+package validators_test
+
+import (
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/validators"
+)
+
+// The same dag-pb block as CIDv0 and CIDv1, from the IPFS documentation.
+const (
+	cidV0 = "QmbWqxBEKC3P8tqsKc98xmWNzrzDtRLMiMPL8wBuTGsMnR"
+	cidV1 = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+)
+
+// rawCID returns the base32 CIDv1 of the raw content and its SHA-256 digest.
+func rawCID(content string) (string, [32]byte) {
+	digest := sha256.Sum256([]byte(content))
+	bz := append([]byte{0x01, validators.CIDCodecRaw, validators.MultihashSHA2256, 0x20}, digest[:]...)
+	return "b" + strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(bz)), digest
+}
+
+func TestParseCID_V0AndV1(t *testing.T) {
+	v0, err := validators.ParseCID(cidV0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), v0.Version)
+	require.Equal(t, uint64(validators.CIDCodecDagPB), v0.Codec)
+	require.True(t, v0.Multihash.IsSHA256())
+
+	v1, err := validators.ParseCID(cidV1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), v1.Version)
+	require.Equal(t, uint64(validators.CIDCodecDagPB), v1.Codec)
+	require.Equal(t, v0.Multihash, v1.Multihash)
+}
+
+func TestParseCID_Multibases(t *testing.T) {
+	cid, digest := rawCID("hello")
+	parsed, err := validators.ParseCID(cid)
+	require.NoError(t, err)
+
+	bz := append([]byte{0x01, validators.CIDCodecRaw, validators.MultihashSHA2256, 0x20}, digest[:]...)
+	for _, encoded := range []string{
+		"B" + base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(bz),
+		"f" + hex.EncodeToString(bz),
+		"F" + strings.ToUpper(hex.EncodeToString(bz)),
+		"m" + base64.RawStdEncoding.EncodeToString(bz),
+		"u" + base64.RawURLEncoding.EncodeToString(bz),
+	} {
+		other, err := validators.ParseCID(encoded)
+		require.NoError(t, err, encoded)
+		require.Equal(t, parsed, other, encoded)
+	}
+
+	require.Equal(t, uint64(validators.CIDCodecRaw), parsed.Codec)
+	require.Equal(t, digest[:], parsed.Multihash.Digest)
+}
+
+func TestParseCID_Invalid(t *testing.T) {
+	cid, _ := rawCID("hello")
+
+	for _, tc := range []struct {
+		desc string
+		cid  string
+		msg  string
+	}{
+		{desc: "empty", cid: "", msg: "too short"},
+		{desc: "unknown multibase", cid: "x" + cid[1:], msg: "unsupported multibase prefix 'x'"},
+		{desc: "mixed case base32", cid: "b" + strings.ToUpper(cid[1:]), msg: "has to be lowercase"},
+		{desc: "invalid base58 character", cid: cidV0[:45] + "0", msg: "invalid character '0'"},
+		{desc: "truncated digest", cid: cid[:len(cid)-4], msg: "multihash digest is"},
+		{desc: "unsupported version", cid: "f02" + strings.Repeat("0", 10), msg: "unsupported CID version"},
+		{desc: "too long base58", cid: "z" + strings.Repeat("2", 300), msg: "too long, max is 289 characters"},
+		{desc: "too long CIDv0", cid: cidV0 + "1", msg: "unsupported multibase prefix 'Q'"},
+		{desc: "wrong SHA-256 length", cid: "f01551210" + strings.Repeat("00", 16), msg: "has to be 32 bytes long"},
+	} {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := validators.ParseCID(tc.cid)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.msg)
+
+			var vErr *validators.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, validators.FieldCID, vErr.Field)
+			require.Equal(t, validators.RuleCIDInvalid, vErr.Rule)
+		})
+	}
+}
+
+func TestCID_VerifyDigest(t *testing.T) {
+	cid, digest := rawCID("hello")
+	parsed, err := validators.ParseCID(cid)
+	require.NoError(t, err)
+
+	require.NoError(t, parsed.VerifyDigest(hex.EncodeToString(digest[:])))
+	require.NoError(t, parsed.VerifyDigest(strings.ToUpper(hex.EncodeToString(digest[:]))))
+	require.NoError(t, parsed.VerifyDigest(base64.RawURLEncoding.EncodeToString(digest[:])))
+
+	var vErr *validators.ValidationError
+	err = parsed.VerifyDigest(validators.SHA256HashOfURL("other"))
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleURIHashCIDMismatch, vErr.Rule)
+
+	err = parsed.VerifyDigest("not a hash")
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleURIHashInvalidFormat, vErr.Rule)
+
+	// identity multihashes cannot be compared with a SHA-256 hash
+	identity, err := validators.ParseCID("f015500" + "03" + hex.EncodeToString([]byte("abc")))
+	require.NoError(t, err)
+	err = identity.VerifyDigest(hex.EncodeToString(digest[:]))
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleURIHashCIDMismatch, vErr.Rule)
+}
+
+func TestParseContentURI(t *testing.T) {
+	parsed, err := validators.ParseContentURI("ipfs://" + cidV1 + "/metadata/moon.json")
+	require.NoError(t, err)
+	require.Equal(t, validators.IPFSScheme, parsed.Scheme)
+	require.Equal(t, cidV1, parsed.ID)
+	require.Equal(t, "metadata/moon.json", parsed.Path)
+	require.Equal(t, uint64(1), parsed.CID.Version)
+
+	txID := base64.RawURLEncoding.EncodeToString([]byte(strings.Repeat("\xfb", 32)))
+	require.Len(t, txID, 43)
+	parsed, err = validators.ParseContentURI("AR://" + txID)
+	require.NoError(t, err)
+	require.Equal(t, validators.ArweaveScheme, parsed.Scheme)
+	require.Equal(t, txID, parsed.ID)
+
+	for _, tc := range []struct {
+		uri  string
+		rule string
+	}{
+		{uri: "https://example.com", rule: validators.RuleURISchemeNotAllowed},
+		{uri: "ipfs://QmNotACID", rule: validators.RuleCIDInvalid},
+		{uri: "ar://" + txID[:42], rule: validators.RuleURIInvalidArweaveID},
+		{uri: "ar://" + strings.ReplaceAll(txID, "-", "+"), rule: validators.RuleURIInvalidArweaveID},
+	} {
+		_, err := validators.ParseContentURI(tc.uri)
+		var vErr *validators.ValidationError
+		require.True(t, errors.As(err, &vErr), tc.uri)
+		require.Equal(t, validators.FieldURI, vErr.Field, tc.uri)
+		require.Equal(t, tc.rule, vErr.Rule, tc.uri)
+	}
+
+	require.True(t, validators.IsContentURI("ipfs://"+cidV0))
+	require.True(t, validators.IsContentURI("ar://"+txID))
+	require.False(t, validators.IsContentURI("https://example.com"))
+}
+
+func TestValidateDenomMetadata_ContentURI(t *testing.T) {
+	cid, digest := rawCID(`{"name":"Moon Token"}`)
+
+	metadata := validMetadata()
+	metadata.URI = "ipfs://" + cid
+	metadata.URIHash = hex.EncodeToString(digest[:])
+	require.NoError(t, validators.ValidateDenomMetadata(metadata))
+
+	metadata.URIHash = base64.StdEncoding.EncodeToString(digest[:])
+	require.NoError(t, validators.ValidateDenomMetadata(metadata))
+
+	metadata.URIHash = validators.SHA256HashOfURL("other")
+	err := validators.ValidateDenomMetadata(metadata)
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleURIHashCIDMismatch, vErr.Rule)
+
+	// the digest of a dag-pb CID is the hash of the root block, not of the document
+	documentHash, err := validators.HashDocumentFile("testdata/metadata.json")
+	require.NoError(t, err)
+	for _, uri := range []string{
+		"ipfs://QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG",
+		"ipfs://bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi",
+		// a path selects a file inside the content
+		"ipfs://" + cid + "/metadata.json",
+	} {
+		metadata.URI = uri
+		metadata.URIHash = documentHash.Hex()
+		require.NoError(t, validators.ValidateDenomMetadata(metadata), uri)
+	}
+
+	metadata.URI = "ipfs://bafyinvalid"
+	metadata.URIHash = ""
+	err = validators.ValidateDenomMetadata(metadata)
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.FieldURI, vErr.Field)
+	require.Equal(t, validators.RuleCIDInvalid, vErr.Rule)
+
+	// arweave transaction IDs are not content hashes, any well-formed uri hash is accepted
+	metadata.URI = "ar://" + base64.RawURLEncoding.EncodeToString(digest[:])
+	metadata.URIHash = validators.SHA256HashOfURL("document")
+	require.NoError(t, validators.ValidateDenomMetadata(metadata))
+}
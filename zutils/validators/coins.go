@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"regexp"
 
-	errorsmod "cosmossdk.io/errors"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"zigchain/zutils/constants"
@@ -58,8 +57,11 @@ func CheckCoinAmount(coin sdk.Coin, zeroOK bool) error {
 
 	// Ensure the coin amount is not nil.
 	if coin.Amount.IsNil() {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldAmount,
+			RuleAmountNil,
+			coin.Amount.String(),
 			"invalid coin amount: cannot be nil (%s)",
 			coin.String(),
 		)
@@ -67,8 +69,11 @@ func CheckCoinAmount(coin sdk.Coin, zeroOK bool) error {
 
 	// Ensure the coin amount is not negative.
 	if coin.IsNegative() {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldAmount,
+			RuleAmountNegative,
+			coin.Amount.String(),
 			"invalid coin amount: %s cannot be negative (%s)",
 			coin.Amount.String(),
 			coin.String(),
@@ -77,8 +82,11 @@ func CheckCoinAmount(coin sdk.Coin, zeroOK bool) error {
 
 	// If zero amounts are not allowed, ensure the coin amount is positive.
 	if !zeroOK && !coin.IsPositive() {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldAmount,
+			RuleAmountNotPositive,
+			coin.Amount.String(),
 			"invalid coin amount: %s has to be positive (%s)",
 			coin.Amount.String(),
 			coin.String(),
@@ -108,39 +116,51 @@ func CheckCoinAmount(coin sdk.Coin, zeroOK bool) error {
 func CheckDenomString(denom string) error {
 
 	if denom == "" {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldDenom,
+			RuleDenomEmpty,
+			denom,
 			"invalid coin: denomination '%s' cannot be empty (e.g., 10uzig)",
 			denom,
 		)
 	}
 
 	if len(denom) < constants.MinSubDenomLength {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldDenom,
+			RuleDenomTooShort,
+			denom,
 			"invalid coin: '%s' denom name is too short, minimum %d characters e.g. 10uzig",
 			denom,
 			constants.MinSubDenomLength,
-		)
+		).withLimits(constants.MinSubDenomLength, constants.MaxDenomLength)
 
 	}
 
 	if len(denom) > constants.MaxDenomLength {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldDenom,
+			RuleDenomTooLong,
+			denom,
 			"invalid coin: '%s' denom name is too long (%d), maximum %d characters e.g. uzig",
 			denom,
 			len(denom),
 			constants.MaxDenomLength,
-		)
+		).withLimits(constants.MinSubDenomLength, constants.MaxDenomLength)
 
 	}
 
 	// regex check (do it last as it is most expensive)
 	if err := sdk.ValidateDenom(denom); err != nil {
 
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldDenom,
+			RuleDenomInvalidChars,
+			denom,
 			"invalid coin: '%s' only letters (a-z, A-Z), numbers (0-9), dots (.) and forward slashes (/) are allowed e.g. 10uzig",
 			denom,
 		)
@@ -154,8 +174,11 @@ func CheckCoinDenom(coin sdk.Coin) error {
 
 	// Check if the denomination is empty.
 	if coin.Denom == "" {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldDenom,
+			RuleDenomEmpty,
+			coin.Denom,
 			"invalid coin: denomination %s cannot be empty (e.g., 10uzig)",
 			coin.String(),
 		)
@@ -163,34 +186,43 @@ func CheckCoinDenom(coin sdk.Coin) error {
 
 	// Check if the denomination is too short.
 	if len(coin.Denom) < constants.MinSubDenomLength {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldDenom,
+			RuleDenomTooShort,
+			coin.Denom,
 			"invalid coin: '%s' denom name is too short for %s, minimum %d characters e.g. 10uzig",
 			coin.Denom,
 			coin.String(),
 			constants.MinSubDenomLength,
-		)
+		).withLimits(constants.MinSubDenomLength, constants.MaxDenomLength)
 
 	}
 
 	// Check if the denomination is too long.
 	if len(coin.Denom) > constants.MaxDenomLength {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldDenom,
+			RuleDenomTooLong,
+			coin.Denom,
 			"invalid coin: '%s' denom name is too long (%d) for %s, maximum %d characters e.g. uzig",
 			coin.Denom,
 			len(coin.Denom),
 			coin.String(),
 			constants.MaxDenomLength,
-		)
+		).withLimits(constants.MinSubDenomLength, constants.MaxDenomLength)
 
 	}
 
 	// regex check (do it last as it is most expensive)
 	if err := sdk.ValidateDenom(coin.Denom); err != nil {
 
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldDenom,
+			RuleDenomInvalidChars,
+			coin.Denom,
 			"invalid coin: '%s' only letters (a-z, A-Z), numbers (0-9), dots (.) and forward slashes (/) are allowed e.g. 10uzig",
 			coin.String(),
 		)
@@ -201,31 +233,40 @@ func CheckCoinDenom(coin sdk.Coin) error {
 
 func CheckSubDenomString(denom string) error {
 	if denom == "" {
-		return errorsmod.Wrap(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldSubDenom,
+			RuleSubDenomEmpty,
+			denom,
 			"Invalid subdenom name: denom name is empty e.g. uzig",
 		)
 	}
 
 	// first size checks for performance
 	if len(denom) < constants.MinSubDenomLength {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldSubDenom,
+			RuleSubDenomTooShort,
+			denom,
 			"invalid coin: '%s' denom name is too short, minimum %d characters e.g. uzig",
 			denom,
 			constants.MinSubDenomLength,
-		)
+		).withLimits(constants.MinSubDenomLength, constants.MaxSubDenomLength)
 
 	}
 
 	if len(denom) > constants.MaxSubDenomLength {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldSubDenom,
+			RuleSubDenomTooLong,
+			denom,
 			"invalid coin: '%s' denom name is too long (%d), maximum %d characters e.g. uzig",
 			denom,
 			len(denom),
 			constants.MaxSubDenomLength,
-		)
+		).withLimits(constants.MinSubDenomLength, constants.MaxSubDenomLength)
 
 	}
 
@@ -233,8 +274,11 @@ func CheckSubDenomString(denom string) error {
 	firstChar := denom[0]
 	if firstChar < 'a' || firstChar > 'z' {
 		// if !(firstChar >= 'a' && firstChar <= 'z') {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldSubDenom,
+			RuleSubDenomInvalidFirstChar,
+			denom,
 			"invalid coin: '%s' denom name has to start with a lowercase letter e.g. uzig",
 			denom,
 		)
@@ -244,8 +288,11 @@ func CheckSubDenomString(denom string) error {
 	for i := 1; i < len(denom); i++ {
 		if (denom[i] < 'a' || denom[i] > 'z') && (denom[i] < '0' || denom[i] > '9') {
 			// if !((denom[i] >= 'a' && denom[i] <= 'z') || (denom[i] >= '0' && denom[i] <= '9')) {
-			return errorsmod.Wrapf(
+			return wrapValidationError(
 				sdkerrors.ErrInvalidCoins,
+				FieldSubDenom,
+				RuleSubDenomInvalidChars,
+				denom,
 				"invalid coin: '%s' only lowercase letters (a-z) and numbers (0-9) are allowed e.g. uzig123",
 				denom,
 			)
@@ -255,8 +302,11 @@ func CheckSubDenomString(denom string) error {
 	// regex check (do it last as it is most expensive)
 	if err := sdk.ValidateDenom(denom); err != nil {
 
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldSubDenom,
+			RuleSubDenomInvalidChars,
+			denom,
 			"invalid coin: '%s' only letters (a-z, A-Z), numbers (0-9), dots (.) and forward slashes (/) are allowed e.g. uzig",
 			denom,
 		)
@@ -265,39 +315,56 @@ func CheckSubDenomString(denom string) error {
 	return nil
 }
 
+// CheckPoolId checks the format of a pool id: constants.PoolPrefix followed by digits.
+//
+// It is the format check applied to pool ids since the first release and stays lax for the existing callers:
+// leading zeros (zp007) and out-of-range numbers (zp0) pass. Use ParsePoolID to also require the canonical
+// form and the range [1, constants.MaxPoolID], as ClassifyDenom does for pool share denoms.
 func CheckPoolId(poolId string) error {
 	if poolId == "" {
-		return errorsmod.Wrap(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldPoolID,
+			RulePoolIDEmpty,
+			poolId,
 			"Invalid pool id: pool id is empty",
 		)
 	}
 
 	if len(poolId) < constants.MinSubDenomLength {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldPoolID,
+			RulePoolIDTooShort,
+			poolId,
 			"Invalid pool id: '%s' pool id is too short, minimum %d characters",
 			poolId,
 			constants.MinSubDenomLength,
-		)
+		).withLimits(constants.MinSubDenomLength, constants.MaxSubDenomLength)
 
 	}
 
 	if len(poolId) > constants.MaxSubDenomLength {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldPoolID,
+			RulePoolIDTooLong,
+			poolId,
 			"Invalid pool id: '%s' pool id is too long (%d), maximum %d characters",
 			poolId,
 			len(poolId),
 			constants.MaxSubDenomLength,
-		)
+		).withLimits(constants.MinSubDenomLength, constants.MaxSubDenomLength)
 
 	}
 
 	// regex check that pool id start with constants.PoolPrefix and followed by numbers
 	if poolId[:2] != constants.PoolPrefix {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldPoolID,
+			RulePoolIDInvalidPrefix,
+			poolId,
 			"Invalid pool id: '%s', pool id has to start with '%s' followed by numbers e.g. %s123",
 			poolId,
 			constants.PoolPrefix,
@@ -306,8 +373,11 @@ func CheckPoolId(poolId string) error {
 	}
 
 	if !regexPoolID.MatchString(poolId) {
-		return errorsmod.Wrapf(
+		return wrapValidationError(
 			sdkerrors.ErrInvalidCoins,
+			FieldPoolID,
+			RulePoolIDInvalidFormat,
+			poolId,
 			"Invalid pool id: '%s', pool id has to start with '%s' followed by numbers e.g. %s123",
 			poolId,
 			constants.PoolPrefix,
@@ -316,3 +386,143 @@ func CheckPoolId(poolId string) error {
 	}
 	return nil
 }
+
+// CoinsCheckOptions configures the policy applied by CoinsCheck.
+type CoinsCheckOptions struct {
+	// ZeroOK allows coins with a zero amount.
+	ZeroOK bool
+	// EmptyOK allows an empty list of coins.
+	EmptyOK bool
+	// MaxEntries is the maximum number of coins, 0 means no limit.
+	MaxEntries int
+	// AllowedDenoms, when not empty, is the only set of denoms accepted.
+	AllowedDenoms []string
+	// DeniedDenoms is a set of denoms that are rejected.
+	DeniedDenoms []string
+	// RequiredDenom, when not empty, has to be present in the coins.
+	RequiredDenom string
+}
+
+// CoinsCheck validates a list of coins against the given policy.
+//
+// It performs the following checks:
+// 1. The list is not empty, unless opts.EmptyOK is set.
+// 2. The list has at most opts.MaxEntries coins.
+// 3. Each coin is valid according to CheckCoinAmount and CheckCoinDenom.
+// 4. The coins are sorted by denom and no denom appears twice.
+// 5. Each denom is in opts.AllowedDenoms (if set) and not in opts.DeniedDenoms.
+// 6. opts.RequiredDenom (if set) is present.
+//
+// Parameters:
+//   - coins: The coins to validate.
+//   - opts: The policy to apply.
+//
+// Returns:
+//   - error: nil if the coins are valid, otherwise ValidationErrors holding every violation found.
+func CoinsCheck(coins sdk.Coins, opts CoinsCheckOptions) error {
+	var errs ValidationErrors
+
+	if len(coins) == 0 && !opts.EmptyOK {
+		errs = errs.add(wrapValidationError(
+			sdkerrors.ErrInvalidCoins,
+			FieldCoins,
+			RuleCoinsEmpty,
+			coins.String(),
+			"invalid coins: at least one coin is required",
+		))
+	}
+
+	if opts.MaxEntries > 0 && len(coins) > opts.MaxEntries {
+		errs = errs.add(wrapValidationError(
+			sdkerrors.ErrInvalidCoins,
+			FieldCoins,
+			RuleCoinsTooMany,
+			coins.String(),
+			"invalid coins: %d coins given, maximum %d",
+			len(coins),
+			opts.MaxEntries,
+		).withLimits(0, opts.MaxEntries))
+	}
+
+	allowed := toSet(opts.AllowedDenoms)
+	denied := toSet(opts.DeniedDenoms)
+	requiredFound := false
+
+	for i, coin := range coins {
+		if err := CoinCheck(coin, opts.ZeroOK); err != nil {
+			errs = errs.add(err)
+		}
+
+		if i > 0 {
+			switch prev := coins[i-1].Denom; {
+			case prev == coin.Denom:
+				errs = errs.add(wrapValidationError(
+					sdkerrors.ErrInvalidCoins,
+					FieldCoins,
+					RuleCoinsDuplicateDenom,
+					coin.Denom,
+					"invalid coins: duplicate denomination '%s'",
+					coin.Denom,
+				))
+			case prev > coin.Denom:
+				errs = errs.add(wrapValidationError(
+					sdkerrors.ErrInvalidCoins,
+					FieldCoins,
+					RuleCoinsNotSorted,
+					coin.Denom,
+					"invalid coins: '%s' is not sorted, it has to come before '%s'",
+					coin.Denom,
+					prev,
+				))
+			}
+		}
+
+		if len(allowed) > 0 && !allowed[coin.Denom] {
+			errs = errs.add(wrapValidationError(
+				sdkerrors.ErrInvalidCoins,
+				FieldCoins,
+				RuleCoinsDenomNotAllowed,
+				coin.Denom,
+				"invalid coins: denomination '%s' is not allowed",
+				coin.Denom,
+			))
+		}
+
+		if denied[coin.Denom] {
+			errs = errs.add(wrapValidationError(
+				sdkerrors.ErrInvalidCoins,
+				FieldCoins,
+				RuleCoinsDenomDenied,
+				coin.Denom,
+				"invalid coins: denomination '%s' is denied",
+				coin.Denom,
+			))
+		}
+
+		if coin.Denom == opts.RequiredDenom {
+			requiredFound = true
+		}
+	}
+
+	if opts.RequiredDenom != "" && !requiredFound {
+		errs = errs.add(wrapValidationError(
+			sdkerrors.ErrInvalidCoins,
+			FieldCoins,
+			RuleCoinsMissingDenom,
+			opts.RequiredDenom,
+			"invalid coins: denomination '%s' is required",
+			opts.RequiredDenom,
+		))
+	}
+
+	return errs.errOrNil()
+}
+
+// toSet converts a list of strings to a set.
+func toSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		set[item] = true
+	}
+	return set
+}
@@ -1,6 +1,7 @@
 package validators_test
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -619,3 +620,150 @@ func TestCheckPoolId_InvalidFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestCoinsCheck_Valid(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		coins sdk.Coins
+		opts  validators.CoinsCheckOptions
+	}{
+		{
+			desc:  "single coin",
+			coins: sdk.Coins{sdk.NewInt64Coin("uzig", 10)},
+		},
+		{
+			desc:  "sorted coins",
+			coins: sdk.Coins{sdk.NewInt64Coin("abc", 1), sdk.NewInt64Coin("uzig", 10)},
+		},
+		{
+			desc:  "empty allowed",
+			coins: sdk.Coins{},
+			opts:  validators.CoinsCheckOptions{EmptyOK: true},
+		},
+		{
+			desc:  "zero allowed",
+			coins: sdk.Coins{sdk.NewInt64Coin("uzig", 0)},
+			opts:  validators.CoinsCheckOptions{ZeroOK: true},
+		},
+		{
+			desc:  "full policy",
+			coins: sdk.Coins{sdk.NewInt64Coin("abc", 1), sdk.NewInt64Coin("uzig", 10)},
+			opts: validators.CoinsCheckOptions{
+				MaxEntries:    2,
+				AllowedDenoms: []string{"abc", "uzig"},
+				DeniedDenoms:  []string{"bad"},
+				RequiredDenom: "uzig",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.NoError(t, validators.CoinsCheck(tc.coins, tc.opts))
+		})
+	}
+}
+
+func TestCoinsCheck_Invalid(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		coins sdk.Coins
+		opts  validators.CoinsCheckOptions
+		rules []string
+	}{
+		{
+			desc:  "empty",
+			coins: sdk.Coins{},
+			rules: []string{validators.RuleCoinsEmpty},
+		},
+		{
+			desc:  "too many",
+			coins: sdk.Coins{sdk.NewInt64Coin("abc", 1), sdk.NewInt64Coin("uzig", 10)},
+			opts:  validators.CoinsCheckOptions{MaxEntries: 1},
+			rules: []string{validators.RuleCoinsTooMany},
+		},
+		{
+			desc:  "zero amount",
+			coins: sdk.Coins{sdk.NewInt64Coin("uzig", 0)},
+			rules: []string{validators.RuleAmountNotPositive},
+		},
+		{
+			desc:  "not sorted",
+			coins: sdk.Coins{sdk.NewInt64Coin("uzig", 10), sdk.NewInt64Coin("abc", 1)},
+			rules: []string{validators.RuleCoinsNotSorted},
+		},
+		{
+			desc:  "duplicate",
+			coins: sdk.Coins{sdk.NewInt64Coin("uzig", 10), sdk.NewInt64Coin("uzig", 1)},
+			rules: []string{validators.RuleCoinsDuplicateDenom},
+		},
+		{
+			desc:  "not allowed",
+			coins: sdk.Coins{sdk.NewInt64Coin("abc", 1)},
+			opts:  validators.CoinsCheckOptions{AllowedDenoms: []string{"uzig"}},
+			rules: []string{validators.RuleCoinsDenomNotAllowed},
+		},
+		{
+			desc:  "denied",
+			coins: sdk.Coins{sdk.NewInt64Coin("abc", 1)},
+			opts:  validators.CoinsCheckOptions{DeniedDenoms: []string{"abc"}},
+			rules: []string{validators.RuleCoinsDenomDenied},
+		},
+		{
+			desc:  "missing required",
+			coins: sdk.Coins{sdk.NewInt64Coin("abc", 1)},
+			opts:  validators.CoinsCheckOptions{RequiredDenom: "uzig"},
+			rules: []string{validators.RuleCoinsMissingDenom},
+		},
+		{
+			desc: "every violation is reported",
+			coins: sdk.Coins{
+				sdk.NewInt64Coin("uzig", 0),
+				sdk.Coin{Denom: "ab", Amount: math.NewInt(1)},
+				sdk.NewInt64Coin("bad", 1),
+			},
+			opts: validators.CoinsCheckOptions{
+				MaxEntries:    2,
+				DeniedDenoms:  []string{"bad"},
+				RequiredDenom: "req",
+			},
+			rules: []string{
+				validators.RuleCoinsTooMany,
+				validators.RuleAmountNotPositive,
+				validators.RuleDenomTooShort,
+				validators.RuleCoinsNotSorted,
+				validators.RuleCoinsDenomDenied,
+				validators.RuleCoinsMissingDenom,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := validators.CoinsCheck(tc.coins, tc.opts)
+			require.Error(t, err)
+			require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+
+			var vErrs validators.ValidationErrors
+			require.True(t, errors.As(err, &vErrs))
+
+			rules := make([]string, len(vErrs))
+			for i, vErr := range vErrs {
+				rules[i] = vErr.Rule
+			}
+			require.Equal(t, tc.rules, rules)
+		})
+	}
+}
+
+func TestCoinsCheck_ErrorMessage(t *testing.T) {
+	coins := sdk.Coins{sdk.NewInt64Coin("uzig", 10), sdk.NewInt64Coin("uzig", 1)}
+
+	err := validators.CoinsCheck(coins, validators.CoinsCheckOptions{RequiredDenom: "abc"})
+	require.Equal(
+		t,
+		"invalid coins: duplicate denomination 'uzig': invalid coins; "+
+			"invalid coins: denomination 'abc' is required: invalid coins",
+		err.Error(),
+	)
+}
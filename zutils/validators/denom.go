@@ -0,0 +1,143 @@
+package validators
+
+import (
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"zigchain/zutils/constants"
+)
+
+// DenomKind is the kind of token a denom refers to.
+type DenomKind uint8
+
+const (
+	// DenomKindUnknown is returned together with an error when the denom cannot be classified.
+	DenomKindUnknown DenomKind = iota
+	// DenomKindNative is a plain bank denom such as constants.BondDenom.
+	DenomKindNative
+	// DenomKindFactory is a factory/{creator}/{subdenom} denom.
+	DenomKindFactory
+	// DenomKindIBC is an ibc/{hash} voucher denom.
+	DenomKindIBC
+	// DenomKindPoolShare is a dex pool share denom, e.g. zp123.
+	DenomKindPoolShare
+	// DenomKindCW20 is a CosmWasm CW20 token, cw20:{contract}.
+	// It is not a bank denom: ':' is not allowed by the denom regex, so it never passes CheckDenomString.
+	DenomKindCW20
+)
+
+// String returns the name of the denom kind.
+func (k DenomKind) String() string {
+	switch k {
+	case DenomKindNative:
+		return "native"
+	case DenomKindFactory:
+		return "factory"
+	case DenomKindIBC:
+		return "ibc"
+	case DenomKindPoolShare:
+		return "pool_share"
+	case DenomKindCW20:
+		return "cw20"
+	default:
+		return "unknown"
+	}
+}
+
+// ParsedDenom holds the components of a classified denom.
+// Only the fields matching Kind are set.
+type ParsedDenom struct {
+	Kind  DenomKind
+	Denom string
+
+	// Factory is set for DenomKindFactory.
+	Factory FactoryDenom
+	// IBCHash is the uppercase hash of an ibc/{hash} denom, set for DenomKindIBC.
+	IBCHash string
+	// PoolID is the pool id, set for DenomKindPoolShare (same as Denom).
+	PoolID string
+	// Contract is the contract address, set for DenomKindCW20.
+	Contract string
+}
+
+// ClassifyDenom determines the kind of the given denom and validates it according to the rules of that kind.
+//
+// Classification is done on the prefix:
+//   - factory/...      -> DenomKindFactory, validated with ParseFactoryDenom
+//   - ibc/...          -> DenomKindIBC, the hash has to be a SHA-256 hex string
+//   - cw20:...         -> DenomKindCW20, the contract has to pass AddressCheck
+//   - zp + digit...    -> DenomKindPoolShare, validated with ParsePoolID (canonical and in range)
+//   - no separator     -> DenomKindNative, validated with CheckDenomString, e.g. uzig, ibcx or zpay
+//
+// Any other denom containing a separator, e.g. foo/bar, is not a kind known to the chain and is rejected.
+//
+// Returns:
+//   - DenomKind: the kind of the denom, DenomKindUnknown on error.
+//   - ParsedDenom: the parsed components of the denom.
+//   - error: nil if the denom is valid for its kind, otherwise a ValidationError.
+func ClassifyDenom(denom string) (DenomKind, ParsedDenom, error) {
+	switch {
+	case IsFactoryDenom(denom):
+		fd, err := ParseFactoryDenom(denom)
+		if err != nil {
+			return DenomKindUnknown, ParsedDenom{}, err
+		}
+		return DenomKindFactory, ParsedDenom{Kind: DenomKindFactory, Denom: denom, Factory: fd}, nil
+
+	case strings.HasPrefix(denom, constants.IBCDenomPrefix+constants.DenomSeparator):
+		hash, err := ParseIBCDenomHash(denom)
+		if err != nil {
+			return DenomKindUnknown, ParsedDenom{}, err
+		}
+		return DenomKindIBC, ParsedDenom{Kind: DenomKindIBC, Denom: denom, IBCHash: hash}, nil
+
+	case strings.HasPrefix(denom, constants.CW20DenomPrefix):
+		contract := strings.TrimPrefix(denom, constants.CW20DenomPrefix)
+		if err := AddressCheck(FieldContract, contract); err != nil {
+			return DenomKindUnknown, ParsedDenom{}, err
+		}
+		return DenomKindCW20, ParsedDenom{Kind: DenomKindCW20, Denom: denom, Contract: contract}, nil
+
+	case isPoolShareDenom(denom):
+		if _, err := ParsePoolID(denom); err != nil {
+			return DenomKindUnknown, ParsedDenom{}, err
+		}
+		return DenomKindPoolShare, ParsedDenom{Kind: DenomKindPoolShare, Denom: denom, PoolID: denom}, nil
+
+	default:
+		if err := checkNativeDenom(denom); err != nil {
+			return DenomKindUnknown, ParsedDenom{}, err
+		}
+		return DenomKindNative, ParsedDenom{Kind: DenomKindNative, Denom: denom}, nil
+	}
+}
+
+// checkNativeDenom validates a denom that is not of any other kind: a plain bank denom without separator.
+func checkNativeDenom(denom string) error {
+	if err := CheckDenomString(denom); err != nil {
+		return err
+	}
+
+	if strings.Contains(denom, constants.DenomSeparator) {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidCoins,
+			FieldDenom,
+			RuleDenomUnknownKind,
+			denom,
+			"invalid denom: '%s' has to be a native denom without '%s', a factory/{creator}/{subdenom}, ibc/{hash} or pool share denom",
+			denom,
+			constants.DenomSeparator,
+		)
+	}
+
+	return nil
+}
+
+// isPoolShareDenom reports whether the denom looks like a pool share denom: the pool prefix followed by a digit.
+// Denoms that merely start with the pool prefix (e.g. zpx or zpay) are native denoms.
+func isPoolShareDenom(denom string) bool {
+	return len(denom) > len(constants.PoolPrefix) &&
+		strings.HasPrefix(denom, constants.PoolPrefix) &&
+		denom[len(constants.PoolPrefix)] >= '0' && denom[len(constants.PoolPrefix)] <= '9'
+}
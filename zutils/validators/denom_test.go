@@ -0,0 +1,199 @@
+package validators_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"zigchain/testutil/sample"
+	"zigchain/zutils/constants"
+	"zigchain/zutils/validators"
+)
+
+func TestClassifyDenom_Valid(t *testing.T) {
+	creator := sample.AccAddress()
+	hash := strings.ToUpper(fmt.Sprintf("%x", sha256.Sum256([]byte("transfer/channel-0/uatom"))))
+
+	tests := []struct {
+		name  string
+		denom string
+		kind  validators.DenomKind
+	}{
+		{
+			name:  "bond denom",
+			denom: constants.BondDenom,
+			kind:  validators.DenomKindNative,
+		},
+		{
+			name:  "native with dash",
+			denom: "unit-zig",
+			kind:  validators.DenomKindNative,
+		},
+		{
+			name:  "factory",
+			denom: fmt.Sprintf("factory/%s/abc", creator),
+			kind:  validators.DenomKindFactory,
+		},
+		{
+			name:  "ibc",
+			denom: "ibc/" + hash,
+			kind:  validators.DenomKindIBC,
+		},
+		{
+			name:  "pool share",
+			denom: "zp123",
+			kind:  validators.DenomKindPoolShare,
+		},
+		{
+			name:  "cw20",
+			denom: "cw20:" + sample.AccAddress(),
+			kind:  validators.DenomKindCW20,
+		},
+		{
+			name:  "native starting with pool prefix",
+			denom: "zpx",
+			kind:  validators.DenomKindNative,
+		},
+		{
+			name:  "native starting with pool prefix word",
+			denom: "zpay",
+			kind:  validators.DenomKindNative,
+		},
+		{
+			name:  "native starting with ibc prefix",
+			denom: "ibcx",
+			kind:  validators.DenomKindNative,
+		},
+		{
+			name:  "native starting with factory prefix",
+			denom: "factoryzig",
+			kind:  validators.DenomKindNative,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, parsed, err := validators.ClassifyDenom(tt.denom)
+			require.NoError(t, err)
+			require.Equal(t, tt.kind, kind)
+			require.Equal(t, tt.kind, parsed.Kind)
+			require.Equal(t, tt.denom, parsed.Denom)
+		})
+	}
+}
+
+func TestClassifyDenom_ParsedFields(t *testing.T) {
+	creator := sample.AccAddress()
+	hash := fmt.Sprintf("%X", sha256.Sum256([]byte("transfer/channel-0/uatom")))
+
+	_, parsed, err := validators.ClassifyDenom(fmt.Sprintf("factory/%s/abc", creator))
+	require.NoError(t, err)
+	require.Equal(t, creator, parsed.Factory.Creator)
+	require.Equal(t, "abc", parsed.Factory.SubDenom)
+
+	_, parsed, err = validators.ClassifyDenom("ibc/" + hash)
+	require.NoError(t, err)
+	require.Equal(t, hash, parsed.IBCHash)
+
+	_, parsed, err = validators.ClassifyDenom("zp42")
+	require.NoError(t, err)
+	require.Equal(t, "zp42", parsed.PoolID)
+
+	_, parsed, err = validators.ClassifyDenom("cw20:" + creator)
+	require.NoError(t, err)
+	require.Equal(t, creator, parsed.Contract)
+}
+
+func TestClassifyDenom_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		denom string
+		rule  string
+	}{
+		{
+			name:  "empty",
+			denom: "",
+			rule:  validators.RuleDenomEmpty,
+		},
+		{
+			name:  "native too short",
+			denom: "ab",
+			rule:  validators.RuleDenomTooShort,
+		},
+		{
+			name:  "factory with invalid creator",
+			denom: "factory/invalid/abc",
+			rule:  validators.RuleAddressInvalidBech32,
+		},
+		{
+			name:  "ibc with short hash",
+			denom: "ibc/ABCD",
+			rule:  validators.RuleDenomInvalidIBCHash,
+		},
+		{
+			name:  "ibc with non hex hash",
+			denom: "ibc/" + strings.Repeat("G", 64),
+			rule:  validators.RuleDenomInvalidIBCHash,
+		},
+		{
+			name:  "pool share with letters",
+			denom: "zp12ab",
+			rule:  validators.RulePoolIDInvalidFormat,
+		},
+		{
+			name:  "pool share with leading zeros",
+			denom: "zp007",
+			rule:  validators.RulePoolIDNonCanonical,
+		},
+		{
+			name:  "pool share zero",
+			denom: "zp0",
+			rule:  validators.RulePoolIDOutOfRange,
+		},
+		{
+			name:  "pool share out of range",
+			denom: "zp999999999999",
+			rule:  validators.RulePoolIDOutOfRange,
+		},
+		{
+			name:  "unknown kind with separator",
+			denom: "foo/bar",
+			rule:  validators.RuleDenomUnknownKind,
+		},
+		{
+			name:  "cw20 with invalid contract",
+			denom: "cw20:invalid",
+			rule:  validators.RuleAddressInvalidBech32,
+		},
+		{
+			name:  "cw20 without contract",
+			denom: "cw20:",
+			rule:  validators.RuleAddressEmpty,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, _, err := validators.ClassifyDenom(tt.denom)
+			require.Error(t, err)
+			require.Equal(t, validators.DenomKindUnknown, kind)
+
+			var vErr *validators.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, tt.rule, vErr.Rule)
+		})
+	}
+}
+
+func TestDenomKind_String(t *testing.T) {
+	require.Equal(t, "unknown", validators.DenomKindUnknown.String())
+	require.Equal(t, "native", validators.DenomKindNative.String())
+	require.Equal(t, "factory", validators.DenomKindFactory.String())
+	require.Equal(t, "ibc", validators.DenomKindIBC.String())
+	require.Equal(t, "pool_share", validators.DenomKindPoolShare.String())
+	require.Equal(t, "cw20", validators.DenomKindCW20.String())
+}
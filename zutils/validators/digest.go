@@ -0,0 +1,237 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// DigestAlgorithm is a hash function whose digests are validated by DigestValidator.
+type DigestAlgorithm int
+
+const (
+	// DigestSHA256 is SHA-256, 32 bytes.
+	DigestSHA256 DigestAlgorithm = iota
+	// DigestSHA512 is SHA-512, 64 bytes.
+	DigestSHA512
+	// DigestKeccak256 is Keccak-256 as used by Ethereum, 32 bytes.
+	DigestKeccak256
+)
+
+// Size returns the digest length in bytes, 0 for an unknown algorithm.
+func (a DigestAlgorithm) Size() int {
+	switch a {
+	case DigestSHA256, DigestKeccak256:
+		return 32
+	case DigestSHA512:
+		return 64
+	default:
+		return 0
+	}
+}
+
+// String returns the name of the algorithm.
+func (a DigestAlgorithm) String() string {
+	switch a {
+	case DigestSHA256:
+		return "SHA-256"
+	case DigestSHA512:
+		return "SHA-512"
+	case DigestKeccak256:
+		return "Keccak-256"
+	default:
+		return "unknown"
+	}
+}
+
+// HexCase is the letter case accepted in hex digests.
+type HexCase int
+
+const (
+	// HexCaseAny accepts lowercase, uppercase and mixed case.
+	HexCaseAny HexCase = iota
+	// HexCaseLower accepts lowercase only, the canonical form returned by Normalize.
+	HexCaseLower
+	// HexCaseUpper accepts uppercase only, e.g. the hash of an ibc/{hash} denom.
+	HexCaseUpper
+)
+
+// Lookup tables of the digest alphabets: the value of every character, or invalidChar.
+const invalidChar = 0xff
+
+var (
+	hexLower  = alphabetTable("0123456789abcdef")
+	hexUpper  = alphabetTable("0123456789ABCDEF")
+	hexAny    = alphabetTable("0123456789abcdefABCDEF")
+	base64Std = alphabetTable("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/")
+	base64URL = alphabetTable("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_")
+)
+
+// sha256Hex is the validator of IsSHA256Hash.
+var sha256Hex = NewDigestValidator(DigestSHA256, HashEncodingHex)
+
+// alphabetTable returns the lookup table of an alphabet. The values of hex letters are not needed
+// to validate the digests, only the value of base64 characters is used, to check the unused bits.
+func alphabetTable(alphabet string) *[256]byte {
+	var table [256]byte
+	for i := range table {
+		table[i] = invalidChar
+	}
+	for i := 0; i < len(alphabet); i++ {
+		table[alphabet[i]] = byte(i)
+	}
+	return &table
+}
+
+// DigestValidator checks the text encoding of a digest by scanning its bytes, without allocating.
+//
+//	v := validators.NewDigestValidator(validators.DigestSHA256, validators.HashEncodingHex).WithHexCase(validators.HexCaseLower)
+//	v.IsValid("9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08") // true
+//
+// The accepted forms are strict: HashEncodingHex is exactly 2*Size characters in the configured case,
+// HashEncodingBase64 is padded standard base64, HashEncodingBase64URL is unpadded URL-safe base64,
+// and the unused bits of the last base64 character are zero.
+// Validators are immutable: every method returns a copy, so a base validator can be shared and extended.
+type DigestValidator struct {
+	algorithm DigestAlgorithm
+	encoding  HashEncoding
+	hexCase   HexCase
+	field     string
+}
+
+// NewDigestValidator creates a validator of algorithm digests in the given encoding, accepting any hex case.
+func NewDigestValidator(algorithm DigestAlgorithm, encoding HashEncoding) DigestValidator {
+	return DigestValidator{algorithm: algorithm, encoding: encoding, hexCase: HexCaseAny, field: FieldDigest}
+}
+
+// WithHexCase sets the accepted letter case of hex digests, it has no effect on base64 digests.
+func (v DigestValidator) WithHexCase(c HexCase) DigestValidator {
+	v.hexCase = c
+	return v
+}
+
+// Named sets the field name used in error messages and rule IDs, FieldDigest by default.
+func (v DigestValidator) Named(field string) DigestValidator {
+	v.field = field
+	return v
+}
+
+// EncodedLen returns the length of a valid digest.
+func (v DigestValidator) EncodedLen() int {
+	size := v.algorithm.Size()
+	switch v.encoding {
+	case HashEncodingBase64:
+		return (size + 2) / 3 * 4
+	case HashEncodingBase64URL:
+		return (size*8 + 5) / 6
+	default:
+		return size * 2
+	}
+}
+
+// IsValid reports whether s is a valid digest.
+func (v DigestValidator) IsValid(s string) bool {
+	return v.check(s) == ""
+}
+
+// Validate checks s and returns a ValidationError with rule "<field>.invalid_length",
+// "<field>.invalid_case" or "<field>.invalid_chars" when it is not a valid digest.
+func (v DigestValidator) Validate(s string) error {
+	suffix := v.check(s)
+	if suffix == "" {
+		return nil
+	}
+
+	var reason string
+	switch {
+	case suffix == "invalid_length":
+		reason = fmt.Sprintf("is %d characters long, a %s digest in %s has %d", len(s), v.algorithm, v.encoding, v.EncodedLen())
+	case suffix == "invalid_case" && v.hexCase == HexCaseUpper:
+		reason = "has to be uppercase hex"
+	case suffix == "invalid_case":
+		reason = "has to be lowercase hex"
+	default:
+		reason = fmt.Sprintf("is not a %s digest in %s", v.algorithm, v.encoding)
+	}
+
+	return wrapValidationError(
+		sdkerrors.ErrInvalidRequest,
+		v.field,
+		v.field+"."+suffix,
+		s,
+		"%s '%s' %s",
+		v.field,
+		s,
+		reason,
+	).withLimits(v.EncodedLen(), v.EncodedLen())
+}
+
+// Normalize validates s and returns its canonical form: lowercase for hex digests, s itself for base64.
+func (v DigestValidator) Normalize(s string) (string, error) {
+	if err := v.Validate(s); err != nil {
+		return "", err
+	}
+	if v.encoding == HashEncodingHex {
+		return strings.ToLower(s), nil
+	}
+	return s, nil
+}
+
+// check returns the suffix of the violated rule, or an empty string for a valid digest.
+func (v DigestValidator) check(s string) string {
+	if v.algorithm.Size() == 0 || len(s) != v.EncodedLen() {
+		return "invalid_length"
+	}
+
+	switch v.encoding {
+	case HashEncodingBase64:
+		return checkBase64(s, base64Std, true, v.algorithm.Size())
+	case HashEncodingBase64URL:
+		return checkBase64(s, base64URL, false, v.algorithm.Size())
+	}
+
+	table := hexAny
+	switch v.hexCase {
+	case HexCaseLower:
+		table = hexLower
+	case HexCaseUpper:
+		table = hexUpper
+	}
+
+	for i := 0; i < len(s); i++ {
+		if table[s[i]] != invalidChar {
+			continue
+		}
+		if hexAny[s[i]] != invalidChar {
+			return "invalid_case"
+		}
+		return "invalid_chars"
+	}
+
+	return ""
+}
+
+// checkBase64 checks a base64 digest of size bytes whose length was already checked.
+func checkBase64(s string, table *[256]byte, padded bool, size int) string {
+	dataLen := (size*8 + 5) / 6
+	for i := dataLen; i < len(s); i++ {
+		if !padded || s[i] != '=' {
+			return "invalid_chars"
+		}
+	}
+
+	for i := 0; i < dataLen; i++ {
+		if table[s[i]] == invalidChar {
+			return "invalid_chars"
+		}
+	}
+
+	// the last character carries unused low bits, they have to be zero as in base64.Encoding.Strict
+	unused := uint(dataLen*6 - size*8)
+	if table[s[dataLen-1]]&(1<<unused-1) != 0 {
+		return "invalid_chars"
+	}
+
+	return ""
+}
@@ -0,0 +1,251 @@
+package validators_test
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/validators"
+)
+
+// isSHA256HashRegexp is the previous implementation of IsSHA256Hash, kept as the reference of the fuzz test
+// and the baseline of the benchmarks.
+func isSHA256HashRegexp(input string) bool {
+	match, _ := regexp.MatchString(`^[a-fA-F0-9]{64}$`, input)
+	return match
+}
+
+func TestDigestValidator_Valid(t *testing.T) {
+	sum256 := sha256.Sum256([]byte("test"))
+	sum512 := sha512.Sum512([]byte("test"))
+
+	testCases := []struct {
+		desc      string
+		validator validators.DigestValidator
+		digest    string
+	}{
+		{
+			desc:      "SHA-256 hex",
+			validator: validators.NewDigestValidator(validators.DigestSHA256, validators.HashEncodingHex),
+			digest:    hex.EncodeToString(sum256[:]),
+		},
+		{
+			desc:      "SHA-256 mixed case hex",
+			validator: validators.NewDigestValidator(validators.DigestSHA256, validators.HashEncodingHex),
+			digest:    strings.ToUpper(hex.EncodeToString(sum256[:5])) + hex.EncodeToString(sum256[5:]),
+		},
+		{
+			desc:      "SHA-256 base64",
+			validator: validators.NewDigestValidator(validators.DigestSHA256, validators.HashEncodingBase64),
+			digest:    base64.StdEncoding.EncodeToString(sum256[:]),
+		},
+		{
+			desc:      "SHA-256 base64url",
+			validator: validators.NewDigestValidator(validators.DigestSHA256, validators.HashEncodingBase64URL),
+			digest:    base64.RawURLEncoding.EncodeToString(sum256[:]),
+		},
+		{
+			desc:      "SHA-512 hex",
+			validator: validators.NewDigestValidator(validators.DigestSHA512, validators.HashEncodingHex).WithHexCase(validators.HexCaseLower),
+			digest:    hex.EncodeToString(sum512[:]),
+		},
+		{
+			desc:      "SHA-512 base64",
+			validator: validators.NewDigestValidator(validators.DigestSHA512, validators.HashEncodingBase64),
+			digest:    base64.StdEncoding.EncodeToString(sum512[:]),
+		},
+		{
+			desc:      "SHA-512 base64url",
+			validator: validators.NewDigestValidator(validators.DigestSHA512, validators.HashEncodingBase64URL),
+			digest:    base64.RawURLEncoding.EncodeToString(sum512[:]),
+		},
+		{
+			desc:      "Keccak-256 uppercase hex",
+			validator: validators.NewDigestValidator(validators.DigestKeccak256, validators.HashEncodingHex).WithHexCase(validators.HexCaseUpper),
+			// keccak256("")
+			digest: "C5D2460186F7233C927E7DB2DCC703C0E500B653CA82273B7BFAD8045D85A470",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.Len(t, tc.digest, tc.validator.EncodedLen())
+			require.True(t, tc.validator.IsValid(tc.digest))
+			require.NoError(t, tc.validator.Validate(tc.digest))
+		})
+	}
+}
+
+func TestDigestValidator_Invalid(t *testing.T) {
+	sum256 := sha256.Sum256([]byte("test"))
+	hexDigest := hex.EncodeToString(sum256[:])
+	b64Digest := base64.StdEncoding.EncodeToString(sum256[:])
+
+	sha256Hex := validators.NewDigestValidator(validators.DigestSHA256, validators.HashEncodingHex)
+	sha256B64 := validators.NewDigestValidator(validators.DigestSHA256, validators.HashEncodingBase64)
+	sha256B64URL := validators.NewDigestValidator(validators.DigestSHA256, validators.HashEncodingBase64URL)
+
+	testCases := []struct {
+		desc      string
+		validator validators.DigestValidator
+		digest    string
+		rule      string
+	}{
+		{desc: "empty", validator: sha256Hex, digest: "", rule: validators.RuleDigestInvalidLength},
+		{desc: "SHA-512 length", validator: sha256Hex, digest: hexDigest + hexDigest, rule: validators.RuleDigestInvalidLength},
+		{desc: "non hex", validator: sha256Hex, digest: hexDigest[:63] + "g", rule: validators.RuleDigestInvalidChars},
+		{desc: "0x prefix", validator: sha256Hex, digest: "0x" + hexDigest[2:], rule: validators.RuleDigestInvalidChars},
+		{
+			desc:      "uppercase when lowercase is required",
+			validator: sha256Hex.WithHexCase(validators.HexCaseLower),
+			digest:    strings.ToUpper(hexDigest),
+			rule:      validators.RuleDigestInvalidCase,
+		},
+		{
+			desc:      "lowercase when uppercase is required",
+			validator: sha256Hex.WithHexCase(validators.HexCaseUpper),
+			digest:    hexDigest,
+			rule:      validators.RuleDigestInvalidCase,
+		},
+		{desc: "unpadded base64", validator: sha256B64, digest: strings.TrimRight(b64Digest, "="), rule: validators.RuleDigestInvalidLength},
+		{desc: "base64 without padding char", validator: sha256B64, digest: b64Digest[:43] + "A", rule: validators.RuleDigestInvalidChars},
+		{desc: "base64 with url chars", validator: sha256B64, digest: "-" + b64Digest[1:], rule: validators.RuleDigestInvalidChars},
+		{desc: "base64 unused bits set", validator: sha256B64, digest: b64Digest[:42] + "B=", rule: validators.RuleDigestInvalidChars},
+		{desc: "padded base64url", validator: sha256B64URL, digest: base64.URLEncoding.EncodeToString(sum256[:]), rule: validators.RuleDigestInvalidLength},
+		{desc: "base64url with std chars", validator: sha256B64URL, digest: "+" + b64Digest[1:43], rule: validators.RuleDigestInvalidChars},
+		{desc: "unknown algorithm", validator: validators.NewDigestValidator(-1, validators.HashEncodingHex), digest: "", rule: validators.RuleDigestInvalidLength},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.False(t, tc.validator.IsValid(tc.digest))
+
+			err := tc.validator.Validate(tc.digest)
+			var vErr *validators.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, tc.rule, vErr.Rule)
+			require.Equal(t, validators.FieldDigest, vErr.Field)
+		})
+	}
+}
+
+func TestDigestValidator_Messages(t *testing.T) {
+	v := validators.NewDigestValidator(validators.DigestSHA256, validators.HashEncodingHex).Named("uri_hash")
+
+	err := v.Validate("abc")
+	require.EqualError(t, err, "uri_hash 'abc' is 3 characters long, a SHA-256 digest in hex has 64: invalid request")
+
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, "uri_hash.invalid_length", vErr.Rule)
+	require.Equal(t, 64, vErr.Min)
+	require.Equal(t, 64, vErr.Max)
+
+	err = v.WithHexCase(validators.HexCaseUpper).Validate(strings.Repeat("a", 64))
+	require.EqualError(t, err, "uri_hash '"+strings.Repeat("a", 64)+"' has to be uppercase hex: invalid request")
+}
+
+func TestDigestValidator_Normalize(t *testing.T) {
+	sum := sha256.Sum256([]byte("test"))
+	hexDigest := hex.EncodeToString(sum[:])
+
+	v := validators.NewDigestValidator(validators.DigestSHA256, validators.HashEncodingHex)
+	normalized, err := v.Normalize(strings.ToUpper(hexDigest))
+	require.NoError(t, err)
+	require.Equal(t, hexDigest, normalized)
+
+	// the normalized form passes the strictest case rule
+	require.True(t, v.WithHexCase(validators.HexCaseLower).IsValid(normalized))
+
+	b64 := validators.NewDigestValidator(validators.DigestSHA256, validators.HashEncodingBase64)
+	normalized, err = b64.Normalize(base64.StdEncoding.EncodeToString(sum[:]))
+	require.NoError(t, err)
+	require.Equal(t, base64.StdEncoding.EncodeToString(sum[:]), normalized)
+
+	_, err = v.Normalize("xyz")
+	require.Error(t, err)
+}
+
+func FuzzIsSHA256Hash(f *testing.F) {
+	sum := sha256.Sum256([]byte("test"))
+	f.Add(hex.EncodeToString(sum[:]))
+	f.Add(strings.ToUpper(hex.EncodeToString(sum[:])))
+	f.Add(hex.EncodeToString(sum[:]) + "\n")
+	f.Add("")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		require.Equal(t, isSHA256HashRegexp(input), validators.IsSHA256Hash(input), "%q", input)
+	})
+}
+
+func FuzzDigestValidator_Base64(f *testing.F) {
+	sum := sha256.Sum256([]byte("test"))
+	f.Add(base64.StdEncoding.EncodeToString(sum[:]))
+	f.Add(base64.RawURLEncoding.EncodeToString(sum[:]))
+
+	std := validators.NewDigestValidator(validators.DigestSHA256, validators.HashEncodingBase64)
+	url := validators.NewDigestValidator(validators.DigestSHA256, validators.HashEncodingBase64URL)
+
+	f.Fuzz(func(t *testing.T, input string) {
+		bz, err := base64.StdEncoding.Strict().DecodeString(input)
+		require.Equal(t, err == nil && len(bz) == sha256.Size, std.IsValid(input), "%q", input)
+
+		bz, err = base64.RawURLEncoding.Strict().DecodeString(input)
+		require.Equal(t, err == nil && len(bz) == sha256.Size, url.IsValid(input), "%q", input)
+	})
+}
+
+var benchDigests = func() []string {
+	digests := make([]string, 0, 4)
+	for _, s := range []string{"a", "b"} {
+		sum := sha256.Sum256([]byte(s))
+		digests = append(digests, hex.EncodeToString(sum[:]), strings.ToUpper(hex.EncodeToString(sum[:])))
+	}
+	return append(digests, "not a digest", strings.Repeat("f", 63)+"g")
+}()
+
+var benchResult bool
+
+func BenchmarkIsSHA256Hash(b *testing.B) {
+	b.Run("regexp", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchResult = isSHA256HashRegexp(benchDigests[i%len(benchDigests)])
+		}
+	})
+
+	b.Run("scan", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchResult = validators.IsSHA256Hash(benchDigests[i%len(benchDigests)])
+		}
+	})
+}
+
+func BenchmarkDigestValidator_Base64(b *testing.B) {
+	sum := sha512.Sum512([]byte("test"))
+	digest := base64.StdEncoding.EncodeToString(sum[:])
+
+	b.Run("decode", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			bz, err := base64.StdEncoding.Strict().DecodeString(digest)
+			benchResult = err == nil && len(bz) == sha512.Size
+		}
+	})
+
+	b.Run("scan", func(b *testing.B) {
+		v := validators.NewDigestValidator(validators.DigestSHA512, validators.HashEncodingBase64)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			benchResult = v.IsValid(digest)
+		}
+	})
+}
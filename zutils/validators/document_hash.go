@@ -0,0 +1,187 @@
+package validators
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// HashEncoding is the text encoding of a uri_hash.
+type HashEncoding int
+
+const (
+	// HashEncodingHex is lowercase hex, 64 characters; uppercase is accepted when verifying.
+	HashEncodingHex HashEncoding = iota
+	// HashEncodingBase64 is standard base64 with padding, 44 characters; unpadded is accepted when verifying.
+	HashEncodingBase64
+	// HashEncodingBase64URL is URL-safe base64 without padding, 43 characters, see constants.MaxURIHashLength;
+	// padded is accepted when verifying.
+	HashEncodingBase64URL
+)
+
+// String returns the name of the encoding.
+func (e HashEncoding) String() string {
+	switch e {
+	case HashEncodingHex:
+		return "hex"
+	case HashEncodingBase64:
+		return "base64"
+	case HashEncodingBase64URL:
+		return "base64url"
+	default:
+		return "unknown"
+	}
+}
+
+// DocumentHash is the SHA-256 hash of a metadata document, the value certified by a uri_hash.
+type DocumentHash [sha256.Size]byte
+
+// HashDocument hashes the bytes read from r until EOF.
+func HashDocument(r io.Reader) (DocumentHash, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return DocumentHash{}, errorsmod.Wrapf(sdkerrors.ErrIO, "cannot read document: %s", err)
+	}
+
+	var hash DocumentHash
+	copy(hash[:], h.Sum(nil))
+	return hash, nil
+}
+
+// HashDocumentBytes hashes a document held in memory.
+func HashDocumentBytes(document []byte) DocumentHash {
+	return sha256.Sum256(document)
+}
+
+// HashDocumentFile hashes the content of the file at path.
+func HashDocumentFile(path string) (DocumentHash, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return DocumentHash{}, errorsmod.Wrapf(sdkerrors.ErrIO, "cannot open document: %s", err)
+	}
+	defer f.Close()
+
+	return HashDocument(f)
+}
+
+// Encode returns the hash in the given encoding, hex for an unknown encoding.
+func (h DocumentHash) Encode(enc HashEncoding) string {
+	switch enc {
+	case HashEncodingBase64:
+		return base64.StdEncoding.EncodeToString(h[:])
+	case HashEncodingBase64URL:
+		return base64.RawURLEncoding.EncodeToString(h[:])
+	default:
+		return hex.EncodeToString(h[:])
+	}
+}
+
+// Hex returns the hash as lowercase hex, the format of SHA256HashOfURL.
+func (h DocumentHash) Hex() string {
+	return h.Encode(HashEncodingHex)
+}
+
+// Base64 returns the hash as padded standard base64.
+func (h DocumentHash) Base64() string {
+	return h.Encode(HashEncodingBase64)
+}
+
+// Base64URL returns the hash as URL-safe base64 without padding.
+func (h DocumentHash) Base64URL() string {
+	return h.Encode(HashEncodingBase64URL)
+}
+
+// DetectHashEncoding decodes a uri_hash whose encoding is not known.
+//
+// Hex is tried first, as 64 hex characters are never a SHA-256 hash in base64. Base64 is then detected
+// from its alphabet: '-' or '_' is URL-safe, '+' or '/' is standard; a hash using neither is valid in both
+// and reported as HashEncodingBase64URL. Padding is optional for both.
+//
+// Returns:
+//   - HashEncoding: the detected encoding.
+//   - DocumentHash: the decoded hash.
+//   - error: nil if the hash decodes to exactly 32 bytes, otherwise a ValidationError.
+func DetectHashEncoding(uriHash string) (HashEncoding, DocumentHash, error) {
+	var hash DocumentHash
+
+	if len(uriHash) == hex.EncodedLen(sha256.Size) {
+		if bz, err := hex.DecodeString(uriHash); err == nil {
+			copy(hash[:], bz)
+			return HashEncodingHex, hash, nil
+		}
+	}
+
+	encodings := []struct {
+		enc    HashEncoding
+		padded *base64.Encoding
+		raw    *base64.Encoding
+	}{
+		{HashEncodingBase64URL, base64.URLEncoding.Strict(), base64.RawURLEncoding.Strict()},
+		{HashEncodingBase64, base64.StdEncoding.Strict(), base64.RawStdEncoding.Strict()},
+	}
+
+	for _, e := range encodings {
+		decoder := e.raw
+		if strings.HasSuffix(uriHash, "=") {
+			decoder = e.padded
+		}
+		if bz, err := decoder.DecodeString(uriHash); err == nil && len(bz) == sha256.Size {
+			copy(hash[:], bz)
+			return e.enc, hash, nil
+		}
+	}
+
+	return 0, DocumentHash{}, wrapValidationError(
+		sdkerrors.ErrInvalidRequest,
+		FieldURIHash,
+		RuleURIHashInvalidFormat,
+		uriHash,
+		"uri hash: '%s' has to be a SHA-256 hash encoded as hex or base64",
+		uriHash,
+	)
+}
+
+// VerifyDocumentHash checks that uriHash, in any encoding accepted by DetectHashEncoding,
+// is the hash of the bytes read from r.
+func VerifyDocumentHash(r io.Reader, uriHash string) error {
+	_, expected, err := DetectHashEncoding(uriHash)
+	if err != nil {
+		return err
+	}
+
+	actual, err := HashDocument(r)
+	if err != nil {
+		return err
+	}
+
+	if expected != actual {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldURIHash,
+			RuleURIHashMismatch,
+			uriHash,
+			"uri hash: '%s' does not match the document hash %s",
+			uriHash,
+			actual.Hex(),
+		)
+	}
+
+	return nil
+}
+
+// VerifyDocumentFile checks that uriHash is the hash of the content of the file at path, see VerifyDocumentHash.
+func VerifyDocumentFile(path string, uriHash string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return errorsmod.Wrapf(sdkerrors.ErrIO, "cannot open document: %s", err)
+	}
+	defer f.Close()
+
+	return VerifyDocumentHash(f, uriHash)
+}
@@ -0,0 +1,149 @@
+package validators_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/validators"
+)
+
+// The hashes of the fixture files, computed with sha256sum and openssl.
+var documentFixtures = []struct {
+	path      string
+	hex       string
+	base64    string
+	base64URL string
+}{
+	{
+		path:      "testdata/metadata.json",
+		hex:       "71c0b0c55e632edb4fa662736e213b48102978c47e57cbe8e0c6a4e2503e90e3",
+		base64:    "ccCwxV5jLttPpmJzbiE7SBApeMR+V8vo4Mak4lA+kOM=",
+		base64URL: "ccCwxV5jLttPpmJzbiE7SBApeMR-V8vo4Mak4lA-kOM",
+	},
+	{
+		path:      "testdata/logo.bin",
+		hex:       "44f38dffac9fd4cab43be981cabf63a534cfbb1e39b73e2f5853436861edc6f5",
+		base64:    "RPON/6yf1Mq0O+mByr9jpTTPux45tz4vWFNDaGHtxvU=",
+		base64URL: "RPON_6yf1Mq0O-mByr9jpTTPux45tz4vWFNDaGHtxvU",
+	},
+}
+
+func TestHashDocumentFile(t *testing.T) {
+	for _, f := range documentFixtures {
+		t.Run(f.path, func(t *testing.T) {
+			hash, err := validators.HashDocumentFile(f.path)
+			require.NoError(t, err)
+			require.Equal(t, f.hex, hash.Hex())
+			require.Equal(t, f.base64, hash.Base64())
+			require.Equal(t, f.base64URL, hash.Base64URL())
+			require.LessOrEqual(t, len(hash.Base64URL()), 64)
+
+			content, err := os.ReadFile(f.path)
+			require.NoError(t, err)
+			require.Equal(t, hash, validators.HashDocumentBytes(content))
+
+			fromReader, err := validators.HashDocument(bytes.NewReader(content))
+			require.NoError(t, err)
+			require.Equal(t, hash, fromReader)
+
+			// the hash of the document differs from the hash of its URL
+			require.NotEqual(t, validators.SHA256HashOfURL(f.path), hash.Hex())
+		})
+	}
+
+	_, err := validators.HashDocumentFile("testdata/missing.json")
+	require.ErrorIs(t, err, sdkerrors.ErrIO)
+
+	_, err = validators.HashDocument(iotest.ErrReader(errors.New("connection reset")))
+	require.ErrorIs(t, err, sdkerrors.ErrIO)
+	require.Contains(t, err.Error(), "connection reset")
+}
+
+func TestDetectHashEncoding(t *testing.T) {
+	f := documentFixtures[0]
+	expected, err := validators.HashDocumentFile(f.path)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		uriHash string
+		enc     validators.HashEncoding
+	}{
+		{uriHash: f.hex, enc: validators.HashEncodingHex},
+		{uriHash: strings.ToUpper(f.hex), enc: validators.HashEncodingHex},
+		{uriHash: f.base64, enc: validators.HashEncodingBase64},
+		{uriHash: strings.TrimRight(f.base64, "="), enc: validators.HashEncodingBase64},
+		{uriHash: f.base64URL, enc: validators.HashEncodingBase64URL},
+		{uriHash: f.base64URL + "=", enc: validators.HashEncodingBase64URL},
+	} {
+		enc, hash, err := validators.DetectHashEncoding(tc.uriHash)
+		require.NoError(t, err, tc.uriHash)
+		require.Equal(t, tc.enc, enc, tc.uriHash)
+		require.Equal(t, expected, hash, tc.uriHash)
+	}
+
+	for _, uriHash := range []string{
+		"",
+		f.hex[:62],
+		strings.Replace(f.base64, "+", "-", 1),
+		f.base64URL + "==",
+		"not a hash",
+	} {
+		_, _, err := validators.DetectHashEncoding(uriHash)
+		var vErr *validators.ValidationError
+		require.True(t, errors.As(err, &vErr), uriHash)
+		require.Equal(t, validators.RuleURIHashInvalidFormat, vErr.Rule, uriHash)
+	}
+
+	require.Equal(t, "hex", validators.HashEncodingHex.String())
+	require.Equal(t, "base64", validators.HashEncodingBase64.String())
+	require.Equal(t, "base64url", validators.HashEncodingBase64URL.String())
+}
+
+func TestVerifyDocumentFile(t *testing.T) {
+	for _, f := range documentFixtures {
+		t.Run(f.path, func(t *testing.T) {
+			for _, uriHash := range []string{f.hex, f.base64, f.base64URL} {
+				require.NoError(t, validators.VerifyDocumentFile(f.path, uriHash), uriHash)
+			}
+		})
+	}
+
+	// the hash of another fixture
+	err := validators.VerifyDocumentFile(documentFixtures[0].path, documentFixtures[1].base64URL)
+	require.EqualError(
+		t,
+		err,
+		"uri hash: '"+documentFixtures[1].base64URL+"' does not match the document hash "+documentFixtures[0].hex+": invalid request",
+	)
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleURIHashMismatch, vErr.Rule)
+
+	err = validators.VerifyDocumentFile("testdata/missing.json", documentFixtures[0].hex)
+	require.ErrorIs(t, err, sdkerrors.ErrIO)
+}
+
+func TestVerifyDocumentHash(t *testing.T) {
+	content, err := os.ReadFile(documentFixtures[0].path)
+	require.NoError(t, err)
+
+	require.NoError(t, validators.VerifyDocumentHash(bytes.NewReader(content), documentFixtures[0].base64URL))
+
+	// a single changed byte is detected
+	content[0] = ' '
+	err = validators.VerifyDocumentHash(bytes.NewReader(content), documentFixtures[0].base64URL)
+	require.Error(t, err)
+
+	// the hash format is checked before reading the document
+	err = validators.VerifyDocumentHash(iotest.ErrReader(errors.New("unreachable")), "abc")
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleURIHashInvalidFormat, vErr.Rule)
+}
@@ -0,0 +1,330 @@
+package validators
+
+import (
+	"errors"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Codespace is the ABCI codespace of the errors registered by this package.
+const Codespace = "zutils"
+
+// ErrAddressNotAllowed is returned by AddressCheckWithPolicy for a blocked address or a module account
+// that the policy does not accept.
+var ErrAddressNotAllowed = errorsmod.Register(Codespace, 2, "address not allowed")
+
+// Field names reported in ValidationError.Field by the validators in this package.
+const (
+	FieldAddress             = "address"
+	FieldAmount              = "amount"
+	FieldCoins               = "coins"
+	FieldDenom               = "denom"
+	FieldSubDenom            = "subdenom"
+	FieldPoolID              = "pool_id"
+	FieldSender              = "sender"
+	FieldReceiver            = "receiver"
+	FieldMemo                = "memo"
+	FieldSigner              = "signer"
+	FieldCreator             = "creator"
+	FieldContract            = "contract"
+	FieldDenomTrace          = "denom_trace"
+	FieldClientID            = "client_id"
+	FieldConnectionID        = "connection_id"
+	FieldPort                = "port"
+	FieldChannel             = "channel"
+	FieldCounterpartyPort    = "counterparty_port"
+	FieldCounterpartyChannel = "counterparty_channel"
+	FieldCounterpartyConnID  = "counterparty_connection_id"
+	FieldDecimalDifference   = "decimal_difference"
+	FieldRateLimit           = "rate_limit"
+	FieldMaxPercentSend      = "max_percent_send"
+	FieldMaxPercentRecv      = "max_percent_recv"
+	FieldDurationHours       = "duration_hours"
+	FieldWhitelist           = "whitelist"
+	FieldName                = "name"
+	FieldSymbol              = "symbol"
+	FieldDisplay             = "display"
+	FieldDenomUnits          = "denom_units"
+	FieldURI                 = "uri"
+	FieldURIHash             = "uri_hash"
+	FieldCID                 = "cid"
+	FieldDigest              = "digest"
+)
+
+// Rule IDs reported in ValidationError.Rule.
+//
+// Rule IDs are part of the public API: clients and indexers dispatch on them and the
+// frontend uses them as translation keys, so existing values must never change.
+const (
+	// RuleUnclassified is reported for an error that is not a ValidationError, e.g. returned by a dependency.
+	RuleUnclassified = "error.unclassified"
+
+	RuleParamInvalidType = "param.invalid_type"
+
+	RuleAmountNil           = "amount.nil"
+	RuleAmountNegative      = "amount.negative"
+	RuleAmountNotPositive   = "amount.not_positive"
+	RuleAmountInvalidFormat = "amount.invalid_format"
+	RuleAmountTooLarge      = "amount.too_large"
+
+	RuleCoinsEmpty           = "coins.empty"
+	RuleCoinsTooMany         = "coins.too_many"
+	RuleCoinsNotSorted       = "coins.not_sorted"
+	RuleCoinsDuplicateDenom  = "coins.duplicate_denom"
+	RuleCoinsDenomNotAllowed = "coins.denom_not_allowed"
+	RuleCoinsDenomDenied     = "coins.denom_denied"
+	RuleCoinsMissingDenom    = "coins.missing_denom"
+
+	RuleDenomEmpty           = "denom.empty"
+	RuleDenomTooShort        = "denom.too_short"
+	RuleDenomTooLong         = "denom.too_long"
+	RuleDenomInvalidChars    = "denom.invalid_chars"
+	RuleDenomInvalidIBCHash  = "denom.invalid_ibc_hash"
+	RuleDenomIBCHashMismatch = "denom.ibc_hash_mismatch"
+	RuleDenomUnknownKind     = "denom.unknown_kind"
+
+	RuleDenomTraceEmptyBase = "denom_trace.empty_base"
+
+	RuleFactoryDenomInvalidFormat = "factory_denom.invalid_format"
+
+	RuleSubDenomEmpty            = "subdenom.empty"
+	RuleSubDenomTooShort         = "subdenom.too_short"
+	RuleSubDenomTooLong          = "subdenom.too_long"
+	RuleSubDenomInvalidFirstChar = "subdenom.invalid_first_char"
+	RuleSubDenomInvalidChars     = "subdenom.invalid_chars"
+
+	RulePoolIDEmpty         = "pool_id.empty"
+	RulePoolIDTooShort      = "pool_id.too_short"
+	RulePoolIDTooLong       = "pool_id.too_long"
+	RulePoolIDInvalidPrefix = "pool_id.invalid_prefix"
+	RulePoolIDInvalidFormat = "pool_id.invalid_format"
+	RulePoolIDNonCanonical  = "pool_id.non_canonical"
+	RulePoolIDOutOfRange    = "pool_id.out_of_range"
+
+	RuleAddressEmpty         = "address.empty"
+	RuleAddressInvalidBech32 = "address.invalid_bech32"
+	RuleAddressInvalidPrefix = "address.invalid_prefix"
+	RuleAddressEmptyBytes    = "address.empty_bytes"
+	RuleAddressTooLong       = "address.too_long"
+	RuleAddressNotUser       = "address.not_user"
+	RuleAddressNotContract   = "address.not_contract"
+	RuleAddressBlocked       = "address.blocked"
+	RuleAddressModuleAccount = "address.module_account"
+
+	RuleClientIDEmpty         = "client_id.empty"
+	RuleClientIDInvalidFormat = "client_id.invalid_format"
+
+	RuleConnectionIDEmpty         = "connection_id.empty"
+	RuleConnectionIDInvalidFormat = "connection_id.invalid_format"
+
+	RulePortEmpty         = "port.empty"
+	RulePortInvalidLength = "port.invalid_length"
+	RulePortInvalidChars  = "port.invalid_chars"
+
+	RuleChannelEmpty         = "channel.empty"
+	RuleChannelInvalidFormat = "channel.invalid_format"
+
+	RuleIBCRouteIncompleteCounterparty = "ibc_route.incomplete_counterparty"
+	RuleIBCRouteMissingCounterparty    = "ibc_route.missing_counterparty"
+	RuleIBCRouteSameEndpoint           = "ibc_route.same_endpoint"
+
+	RuleMemoTooLong     = "memo.too_long"
+	RuleMemoInvalidJSON = "memo.invalid_json"
+
+	RuleDecimalDifferenceTooLarge = "decimal_difference.too_large"
+
+	RuleRateLimitZeroThresholds  = "rate_limit.zero_thresholds"
+	RuleMaxPercentSendOutOfRange = "max_percent_send.out_of_range"
+	RuleMaxPercentRecvOutOfRange = "max_percent_recv.out_of_range"
+	RuleDurationHoursTooSmall    = "duration_hours.too_small"
+	RuleWhitelistDuplicatePair   = "whitelist.duplicate_pair"
+
+	RuleNameEmpty        = "name.empty"
+	RuleNameTooLong      = "name.too_long"
+	RuleNameInvalidChars = "name.invalid_chars"
+
+	RuleSymbolEmpty        = "symbol.empty"
+	RuleSymbolTooLong      = "symbol.too_long"
+	RuleSymbolInvalidChars = "symbol.invalid_chars"
+
+	RuleDisplayNotFound = "display.not_found"
+
+	RuleDenomUnitsEmpty            = "denom_units.empty"
+	RuleDenomUnitsInvalidBase      = "denom_units.invalid_base"
+	RuleDenomUnitsNotSorted        = "denom_units.not_sorted"
+	RuleDenomUnitsExponentTooLarge = "denom_units.exponent_too_large"
+	RuleDenomUnitsDuplicateDenom   = "denom_units.duplicate_denom"
+
+	RuleURITooLong          = "uri.too_long"
+	RuleURIInvalidFormat    = "uri.invalid_format"
+	RuleURISchemeNotAllowed = "uri.scheme_not_allowed"
+	RuleURIInvalidArweaveID = "uri.invalid_arweave_id"
+
+	RuleURIHashWithoutURI    = "uri_hash.without_uri"
+	RuleURIHashTooLong       = "uri_hash.too_long"
+	RuleURIHashInvalidFormat = "uri_hash.invalid_format"
+	RuleURIHashCIDMismatch   = "uri_hash.cid_mismatch"
+	RuleURIHashMismatch      = "uri_hash.mismatch"
+
+	RuleCIDInvalid = "cid.invalid"
+
+	RuleDigestInvalidLength = "digest.invalid_length"
+	RuleDigestInvalidCase   = "digest.invalid_case"
+	RuleDigestInvalidChars  = "digest.invalid_chars"
+)
+
+// ValidationError is returned by every check in this package.
+//
+// Error() returns exactly the message the check has always produced, so callers
+// comparing messages keep working. On top of that the error exposes:
+//   - Field: the name of the validated field (e.g. "denom" or the field passed to AddressCheck)
+//   - Rule: a stable rule ID (e.g. "denom.too_short") suitable for localisation
+//   - Value: the offending value as a string
+//   - Min, Max: the limits of the violated rule, when the rule has any (zero otherwise)
+//   - Code: the registered error that defines the ABCI codespace and code
+//
+// Use errors.As to access the fields and errors.Is to match against Code.
+type ValidationError struct {
+	Field string
+	Rule  string
+	Value string
+	Min   int
+	Max   int
+	Code  *errorsmod.Error
+
+	err error
+	// cause is the reason of the failure without field and value, used to rebuild the message in another wording.
+	cause error
+}
+
+// newValidationError creates a ValidationError with the given registered error
+// and message-carrying error.
+func newValidationError(code *errorsmod.Error, field, rule, value string, err error) *ValidationError {
+	return &ValidationError{
+		Field: field,
+		Rule:  rule,
+		Value: value,
+		Code:  code,
+		err:   err,
+	}
+}
+
+// wrapValidationError creates a ValidationError whose message is built with
+// errorsmod.Wrapf, i.e. "<message>: <code description>".
+func wrapValidationError(
+	code *errorsmod.Error,
+	field, rule, value string,
+	format string,
+	args ...interface{},
+) *ValidationError {
+	return newValidationError(code, field, rule, value, errorsmod.Wrapf(code, format, args...))
+}
+
+// withLimits sets the limits of the violated rule.
+func (e *ValidationError) withLimits(min, max int) *ValidationError {
+	e.Min = min
+	e.Max = max
+	return e
+}
+
+// withCause sets the reason of the failure, see sdkAddressError.
+func (e *ValidationError) withCause(cause error) *ValidationError {
+	e.cause = cause
+	return e
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ValidationError) Unwrap() error {
+	return e.err
+}
+
+// Is reports whether target is the registered error of this validation error.
+func (e *ValidationError) Is(target error) bool {
+	return target == e.Code
+}
+
+// Codespace returns the ABCI codespace of the registered error.
+func (e *ValidationError) Codespace() string {
+	return e.Code.Codespace()
+}
+
+// ABCICode returns the ABCI code of the registered error.
+func (e *ValidationError) ABCICode() uint32 {
+	return e.Code.ABCICode()
+}
+
+// ValidationErrors is returned by checks that report every violation instead of stopping at the first one.
+//
+// The ABCI codespace and code are the ones of the first error.
+// errors.As and errors.Is inspect every error in the list.
+type ValidationErrors []*ValidationError
+
+// Error joins the messages of all errors.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors of the list.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Codespace returns the ABCI codespace of the first error.
+func (e ValidationErrors) Codespace() string {
+	return e[0].Codespace()
+}
+
+// ABCICode returns the ABCI code of the first error.
+func (e ValidationErrors) ABCICode() uint32 {
+	return e[0].ABCICode()
+}
+
+// add appends err to the list, a nil err is ignored.
+// The errors of a nested ValidationErrors are appended one by one, and an error that is not a ValidationError
+// is wrapped with RuleUnclassified, so a failed check can never be dropped and turn the list into a nil error.
+func (e ValidationErrors) add(err error) ValidationErrors {
+	if err == nil {
+		return e
+	}
+
+	var list ValidationErrors
+	if errors.As(err, &list) {
+		return append(e, list...)
+	}
+
+	var vErr *ValidationError
+	if errors.As(err, &vErr) {
+		return append(e, vErr)
+	}
+
+	// keep the registered error of a wrapped sdk error, so the ABCI code is unchanged
+	code := sdkerrors.ErrInvalidRequest
+	var registered *errorsmod.Error
+	if errors.As(err, &registered) {
+		code = registered
+	}
+	return append(e, newValidationError(code, "", RuleUnclassified, "", err))
+}
+
+// errOrNil returns nil for an empty list, so callers never return a non-nil error interface holding no errors.
+func (e ValidationErrors) errOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
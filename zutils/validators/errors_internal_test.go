@@ -0,0 +1,41 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationErrors_Add(t *testing.T) {
+	var errs ValidationErrors
+	require.NoError(t, errs.add(nil).errOrNil())
+
+	first := wrapValidationError(sdkerrors.ErrInvalidCoins, FieldDenom, RuleDenomEmpty, "", "empty denom")
+	second := wrapValidationError(sdkerrors.ErrInvalidAddress, FieldAddress, RuleAddressEmpty, "", "empty address")
+
+	errs = errs.add(first)
+	// a nested list is flattened
+	errs = errs.add(ValidationErrors{second, first})
+	// a wrapped ValidationError keeps its fields
+	errs = errs.add(fmt.Errorf("context: %w", second))
+	require.Len(t, errs, 4)
+	require.Equal(t, RuleAddressEmpty, errs[3].Rule)
+
+	// an error that is not a ValidationError is never dropped
+	plain := errors.New("connection reset")
+	errs = ValidationErrors{}.add(plain)
+	require.Len(t, errs, 1)
+	require.Equal(t, RuleUnclassified, errs[0].Rule)
+	require.EqualError(t, errs.errOrNil(), "connection reset")
+	require.ErrorIs(t, errs.errOrNil(), plain)
+	require.ErrorIs(t, errs.errOrNil(), sdkerrors.ErrInvalidRequest)
+
+	// the registered error of a wrapped sdk error is kept
+	errs = ValidationErrors{}.add(errorsmod.Wrap(sdkerrors.ErrInvalidCoins, "bad coins"))
+	require.Equal(t, RuleUnclassified, errs[0].Rule)
+	require.Equal(t, sdkerrors.ErrInvalidCoins.ABCICode(), errs.ABCICode())
+}
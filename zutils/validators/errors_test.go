@@ -0,0 +1,140 @@
+package validators_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	clienttypes "github.com/cosmos/ibc-go/v10/modules/core/02-client/types"
+	porttypes "github.com/cosmos/ibc-go/v10/modules/core/05-port/types"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/constants"
+	"zigchain/zutils/validators"
+)
+
+func TestValidationError_Fields(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		code  *errorsmod.Error
+		field string
+		rule  string
+		value string
+		min   int
+		max   int
+	}{
+		{
+			name:  "denom too short",
+			err:   validators.CheckDenomString("ab"),
+			code:  sdkerrors.ErrInvalidCoins,
+			field: validators.FieldDenom,
+			rule:  validators.RuleDenomTooShort,
+			value: "ab",
+			min:   constants.MinSubDenomLength,
+			max:   constants.MaxDenomLength,
+		},
+		{
+			name:  "subdenom invalid first char",
+			err:   validators.CheckSubDenomString("1abc"),
+			code:  sdkerrors.ErrInvalidCoins,
+			field: validators.FieldSubDenom,
+			rule:  validators.RuleSubDenomInvalidFirstChar,
+			value: "1abc",
+		},
+		{
+			name:  "pool id too long",
+			err:   validators.CheckPoolId("zp" + strings.Repeat("1", constants.MaxSubDenomLength)),
+			code:  sdkerrors.ErrInvalidCoins,
+			field: validators.FieldPoolID,
+			rule:  validators.RulePoolIDTooLong,
+			value: "zp" + strings.Repeat("1", constants.MaxSubDenomLength),
+			min:   constants.MinSubDenomLength,
+			max:   constants.MaxSubDenomLength,
+		},
+		{
+			name:  "address empty keeps caller field",
+			err:   validators.AddressCheck("recipient", ""),
+			code:  sdkerrors.ErrInvalidAddress,
+			field: "recipient",
+			rule:  validators.RuleAddressEmpty,
+		},
+		{
+			name:  "signer invalid bech32",
+			err:   validators.SignerCheck("invalidAddress123"),
+			code:  sdkerrors.ErrInvalidAddress,
+			field: validators.FieldSigner,
+			rule:  validators.RuleAddressInvalidBech32,
+			value: "invalidAddress123",
+		},
+		{
+			name:  "port invalid length",
+			err:   validators.ValidatePort("a"),
+			code:  porttypes.ErrInvalidPort,
+			field: validators.FieldPort,
+			rule:  validators.RulePortInvalidLength,
+			value: "a",
+			min:   2,
+			max:   128,
+		},
+		{
+			name:  "client id empty",
+			err:   validators.ValidateClientId(""),
+			code:  clienttypes.ErrInvalidClient,
+			field: validators.FieldClientID,
+			rule:  validators.RuleClientIDEmpty,
+		},
+		{
+			name:  "param of invalid type",
+			err:   validators.ValidateChannel(123),
+			code:  sdkerrors.ErrInvalidType,
+			field: validators.FieldChannel,
+			rule:  validators.RuleParamInvalidType,
+			value: "123",
+		},
+		{
+			name:  "decimal difference too large",
+			err:   validators.ValidateDecimalDifference(uint32(19)),
+			code:  sdkerrors.ErrInvalidRequest,
+			field: validators.FieldDecimalDifference,
+			rule:  validators.RuleDecimalDifferenceTooLarge,
+			value: "19",
+			max:   18,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Error(t, tt.err)
+
+			var vErr *validators.ValidationError
+			require.True(t, errors.As(tt.err, &vErr), "expected a ValidationError, got %T", tt.err)
+			require.Equal(t, tt.field, vErr.Field)
+			require.Equal(t, tt.rule, vErr.Rule)
+			require.Equal(t, tt.value, vErr.Value)
+			require.Equal(t, tt.min, vErr.Min)
+			require.Equal(t, tt.max, vErr.Max)
+
+			// the registered error drives errors.Is and the ABCI codespace/code
+			require.ErrorIs(t, tt.err, tt.code)
+			codespace, code, _ := errorsmod.ABCIInfo(tt.err, false)
+			require.Equal(t, tt.code.Codespace(), codespace)
+			require.Equal(t, tt.code.ABCICode(), code)
+		})
+	}
+}
+
+func TestValidationError_MessageUnchanged(t *testing.T) {
+	// the typed error must not change the messages produced by the checks
+	err := validators.CheckSubDenomString("")
+	require.Equal(t, "Invalid subdenom name: denom name is empty e.g. uzig: invalid coins", err.Error())
+
+	err = validators.ValidatePort("")
+	require.Equal(t, "invalid port: port cannot be empty", err.Error())
+
+	err = validators.ValidateDenom("")
+	require.Equal(t, "denom cannot be empty", err.Error())
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+}
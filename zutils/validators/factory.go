@@ -0,0 +1,101 @@
+package validators
+
+import (
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"zigchain/zutils/constants"
+)
+
+// FactoryDenom is a denom created by the factory module: factory/{creator}/{subdenom}
+type FactoryDenom struct {
+	Creator  string
+	SubDenom string
+}
+
+// NewFactoryDenom creates a factory denom from its creator and subdenom and validates it.
+func NewFactoryDenom(creator string, subDenom string) (FactoryDenom, error) {
+	fd := FactoryDenom{
+		Creator:  creator,
+		SubDenom: subDenom,
+	}
+
+	if err := fd.Validate(); err != nil {
+		return FactoryDenom{}, err
+	}
+
+	return fd, nil
+}
+
+// ParseFactoryDenom splits a factory/{creator}/{subdenom} denom into its components and validates them.
+//
+// Parameters:
+//   - denom: The full denom string to parse.
+//
+// Returns:
+//   - FactoryDenom: the parsed denom.
+//   - error: nil if the denom is a valid factory denom, otherwise a ValidationError.
+func ParseFactoryDenom(denom string) (FactoryDenom, error) {
+	parts := strings.SplitN(denom, constants.DenomSeparator, 3)
+	if len(parts) != 3 || parts[0] != constants.FactoryDenomPrefix {
+		return FactoryDenom{}, wrapValidationError(
+			sdkerrors.ErrInvalidCoins,
+			FieldDenom,
+			RuleFactoryDenomInvalidFormat,
+			denom,
+			"invalid factory denom: '%s' has to be in the format %s%s{creator}%s{subdenom}",
+			denom,
+			constants.FactoryDenomPrefix,
+			constants.DenomSeparator,
+			constants.DenomSeparator,
+		)
+	}
+
+	return NewFactoryDenom(parts[1], parts[2])
+}
+
+// IsFactoryDenom reports whether the denom has the factory prefix.
+// It does not validate the creator or the subdenom, use ParseFactoryDenom for that.
+func IsFactoryDenom(denom string) bool {
+	return strings.HasPrefix(denom, constants.FactoryDenomPrefix+constants.DenomSeparator)
+}
+
+// String returns the full factory/{creator}/{subdenom} denom.
+func (fd FactoryDenom) String() string {
+	return constants.FactoryDenomPrefix +
+		constants.DenomSeparator + fd.Creator +
+		constants.DenomSeparator + fd.SubDenom
+}
+
+// Validate checks the creator, the subdenom and the total length of the denom.
+//
+// It performs the following checks:
+// 1. The creator is a valid address (AddressCheck).
+// 2. The subdenom is a valid subdenom (CheckSubDenomString).
+// 3. The full denom does not exceed constants.MaxDenomLength.
+func (fd FactoryDenom) Validate() error {
+	if err := AddressCheck(FieldCreator, fd.Creator); err != nil {
+		return err
+	}
+
+	if err := CheckSubDenomString(fd.SubDenom); err != nil {
+		return err
+	}
+
+	denom := fd.String()
+	if len(denom) > constants.MaxDenomLength {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidCoins,
+			FieldDenom,
+			RuleDenomTooLong,
+			denom,
+			"invalid factory denom: '%s' is too long (%d), maximum %d characters",
+			denom,
+			len(denom),
+			constants.MaxDenomLength,
+		).withLimits(constants.MinSubDenomLength, constants.MaxDenomLength)
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package validators_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/testutil/sample"
+	"zigchain/zutils/constants"
+	"zigchain/zutils/validators"
+)
+
+func TestParseFactoryDenom_Valid(t *testing.T) {
+	creator := sample.AccAddress()
+	denom := fmt.Sprintf("factory/%s/abc123", creator)
+
+	fd, err := validators.ParseFactoryDenom(denom)
+	require.NoError(t, err)
+	require.Equal(t, creator, fd.Creator)
+	require.Equal(t, "abc123", fd.SubDenom)
+	require.Equal(t, denom, fd.String())
+	require.True(t, validators.IsFactoryDenom(denom))
+}
+
+func TestNewFactoryDenom_RoundTrip(t *testing.T) {
+	creator := sample.AccAddress()
+
+	fd, err := validators.NewFactoryDenom(creator, "bitcoin")
+	require.NoError(t, err)
+
+	parsed, err := validators.ParseFactoryDenom(fd.String())
+	require.NoError(t, err)
+	require.Equal(t, fd, parsed)
+}
+
+func TestParseFactoryDenom_Invalid(t *testing.T) {
+	creator := sample.AccAddress()
+
+	// 64 byte creator address makes the full denom longer than MaxDenomLength
+	longCreator := sdk.AccAddress(make([]byte, 64)).String()
+
+	tests := []struct {
+		name  string
+		denom string
+		rule  string
+		field string
+	}{
+		{
+			name:  "empty",
+			denom: "",
+			rule:  validators.RuleFactoryDenomInvalidFormat,
+			field: validators.FieldDenom,
+		},
+		{
+			name:  "not a factory denom",
+			denom: "uzig",
+			rule:  validators.RuleFactoryDenomInvalidFormat,
+			field: validators.FieldDenom,
+		},
+		{
+			name:  "wrong prefix",
+			denom: fmt.Sprintf("coin/%s/abc", creator),
+			rule:  validators.RuleFactoryDenomInvalidFormat,
+			field: validators.FieldDenom,
+		},
+		{
+			name:  "missing subdenom part",
+			denom: "factory/" + creator,
+			rule:  validators.RuleFactoryDenomInvalidFormat,
+			field: validators.FieldDenom,
+		},
+		{
+			name:  "invalid creator",
+			denom: "factory/invalid/abc",
+			rule:  validators.RuleAddressInvalidBech32,
+			field: validators.FieldCreator,
+		},
+		{
+			name:  "empty creator",
+			denom: "factory//abc",
+			rule:  validators.RuleAddressEmpty,
+			field: validators.FieldCreator,
+		},
+		{
+			name:  "subdenom too short",
+			denom: fmt.Sprintf("factory/%s/ab", creator),
+			rule:  validators.RuleSubDenomTooShort,
+			field: validators.FieldSubDenom,
+		},
+		{
+			name:  "subdenom with extra separator",
+			denom: fmt.Sprintf("factory/%s/abc/def", creator),
+			rule:  validators.RuleSubDenomInvalidChars,
+			field: validators.FieldSubDenom,
+		},
+		{
+			name:  "subdenom with uppercase",
+			denom: fmt.Sprintf("factory/%s/Abc", creator),
+			rule:  validators.RuleSubDenomInvalidFirstChar,
+			field: validators.FieldSubDenom,
+		},
+		{
+			name:  "total length too long",
+			denom: fmt.Sprintf("factory/%s/abcdefghijklmnopqrstuvwxyzabcdefghijklmnopq", longCreator),
+			rule:  validators.RuleDenomTooLong,
+			field: validators.FieldDenom,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validators.ParseFactoryDenom(tt.denom)
+			require.Error(t, err)
+
+			var vErr *validators.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, tt.rule, vErr.Rule)
+			require.Equal(t, tt.field, vErr.Field)
+		})
+	}
+}
+
+func TestParseFactoryDenom_TooLongMessage(t *testing.T) {
+	longCreator := sdk.AccAddress(make([]byte, 64)).String()
+	denom := fmt.Sprintf("factory/%s/abcdefghijklmnopqrstuvwxyzabcdefghijklmnopq", longCreator)
+
+	_, err := validators.ParseFactoryDenom(denom)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+	require.Equal(
+		t,
+		fmt.Sprintf(
+			"invalid factory denom: '%s' is too long (%d), maximum %d characters: invalid coins",
+			denom,
+			len(denom),
+			constants.MaxDenomLength,
+		),
+		err.Error(),
+	)
+}
+
+func TestIsFactoryDenom(t *testing.T) {
+	require.True(t, validators.IsFactoryDenom("factory/anything"))
+	require.False(t, validators.IsFactoryDenom("factory"))
+	require.False(t, validators.IsFactoryDenom("uzig"))
+	require.False(t, validators.IsFactoryDenom("ibc/ABCD"))
+}
@@ -3,21 +3,62 @@ package validators
 import (
 	"fmt"
 
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	clienttypes "github.com/cosmos/ibc-go/v10/modules/core/02-client/types"
+	connectiontypes "github.com/cosmos/ibc-go/v10/modules/core/03-connection/types"
 	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
 	porttypes "github.com/cosmos/ibc-go/v10/modules/core/05-port/types"
+
+	"zigchain/zutils/constants"
 )
 
+// invalidParamTypeError is returned by the param validators when the value has an unexpected type.
+func invalidParamTypeError(field string, i interface{}) error {
+	return newValidationError(
+		sdkerrors.ErrInvalidType,
+		field,
+		RuleParamInvalidType,
+		fmt.Sprintf("%v", i),
+		fmt.Errorf("invalid parameter type: %T", i),
+	)
+}
+
 func ValidateClientId(i interface{}) error {
 	v, ok := i.(string)
 	if !ok {
-		return fmt.Errorf("invalid parameter type: %T", i)
+		return invalidParamTypeError(FieldClientID, i)
 	}
 	if v == "" {
-		return fmt.Errorf("%w: client ID cannot be empty", clienttypes.ErrInvalidClient)
+		return newValidationError(
+			clienttypes.ErrInvalidClient, FieldClientID, RuleClientIDEmpty, v,
+			fmt.Errorf("%w: client ID cannot be empty", clienttypes.ErrInvalidClient),
+		)
 	}
 	if !clienttypes.IsValidClientID(v) {
-		return fmt.Errorf("%w: invalid client ID format", clienttypes.ErrInvalidClient)
+		return newValidationError(
+			clienttypes.ErrInvalidClient, FieldClientID, RuleClientIDInvalidFormat, v,
+			fmt.Errorf("%w: invalid client ID format", clienttypes.ErrInvalidClient),
+		)
+	}
+	return nil
+}
+
+func ValidateConnectionId(i interface{}) error {
+	v, ok := i.(string)
+	if !ok {
+		return invalidParamTypeError(FieldConnectionID, i)
+	}
+	if v == "" {
+		return newValidationError(
+			connectiontypes.ErrInvalidConnectionIdentifier, FieldConnectionID, RuleConnectionIDEmpty, v,
+			fmt.Errorf("%w: connection ID cannot be empty", connectiontypes.ErrInvalidConnectionIdentifier),
+		)
+	}
+	if !connectiontypes.IsValidConnectionID(v) {
+		return newValidationError(
+			connectiontypes.ErrInvalidConnectionIdentifier, FieldConnectionID, RuleConnectionIDInvalidFormat, v,
+			fmt.Errorf("%w: invalid connection ID format", connectiontypes.ErrInvalidConnectionIdentifier),
+		)
 	}
 	return nil
 }
@@ -25,16 +66,25 @@ func ValidateClientId(i interface{}) error {
 func ValidatePort(i interface{}) error {
 	v, ok := i.(string)
 	if !ok {
-		return fmt.Errorf("invalid parameter type: %T", i)
+		return invalidParamTypeError(FieldPort, i)
 	}
 	if v == "" {
-		return fmt.Errorf("%w: port cannot be empty", porttypes.ErrInvalidPort)
+		return newValidationError(
+			porttypes.ErrInvalidPort, FieldPort, RulePortEmpty, v,
+			fmt.Errorf("%w: port cannot be empty", porttypes.ErrInvalidPort),
+		)
 	}
 	if len(v) < 2 || len(v) > 128 {
-		return fmt.Errorf("%w: port length must be between 2 and 128 characters", porttypes.ErrInvalidPort)
+		return newValidationError(
+			porttypes.ErrInvalidPort, FieldPort, RulePortInvalidLength, v,
+			fmt.Errorf("%w: port length must be between 2 and 128 characters", porttypes.ErrInvalidPort),
+		).withLimits(2, 128)
 	}
 	if !IsValidIdentifier(v) {
-		return fmt.Errorf("%w: port contains invalid characters. Only alphanumeric, ., _, +, -, #, [, ], <, > are allowed", porttypes.ErrInvalidPort)
+		return newValidationError(
+			porttypes.ErrInvalidPort, FieldPort, RulePortInvalidChars, v,
+			fmt.Errorf("%w: port contains invalid characters. Only alphanumeric, ., _, +, -, #, [, ], <, > are allowed", porttypes.ErrInvalidPort),
+		)
 	}
 	return nil
 }
@@ -42,13 +92,19 @@ func ValidatePort(i interface{}) error {
 func ValidateChannel(i interface{}) error {
 	v, ok := i.(string)
 	if !ok {
-		return fmt.Errorf("invalid parameter type: %T", i)
+		return invalidParamTypeError(FieldChannel, i)
 	}
 	if v == "" {
-		return fmt.Errorf("%w: channel cannot be empty", channeltypes.ErrInvalidChannelIdentifier)
+		return newValidationError(
+			channeltypes.ErrInvalidChannelIdentifier, FieldChannel, RuleChannelEmpty, v,
+			fmt.Errorf("%w: channel cannot be empty", channeltypes.ErrInvalidChannelIdentifier),
+		)
 	}
 	if !channeltypes.IsValidChannelID(v) {
-		return fmt.Errorf("%w: invalid channel ID format", channeltypes.ErrInvalidChannelIdentifier)
+		return newValidationError(
+			channeltypes.ErrInvalidChannelIdentifier, FieldChannel, RuleChannelInvalidFormat, v,
+			fmt.Errorf("%w: invalid channel ID format", channeltypes.ErrInvalidChannelIdentifier),
+		)
 	}
 	return nil
 }
@@ -56,13 +112,19 @@ func ValidateChannel(i interface{}) error {
 func ValidateDenom(i interface{}) error {
 	v, ok := i.(string)
 	if !ok {
-		return fmt.Errorf("invalid parameter type: %T", i)
+		return invalidParamTypeError(FieldDenom, i)
 	}
 	if v == "" {
-		return fmt.Errorf("denom cannot be empty")
+		return newValidationError(
+			sdkerrors.ErrInvalidCoins, FieldDenom, RuleDenomEmpty, v,
+			fmt.Errorf("denom cannot be empty"),
+		)
 	}
 	if !IsValidIdentifier(v) {
-		return fmt.Errorf("denom contains invalid characters. Only alphanumeric, ., _, +, -, #, [, ], <, > are allowed")
+		return newValidationError(
+			sdkerrors.ErrInvalidCoins, FieldDenom, RuleDenomInvalidChars, v,
+			fmt.Errorf("denom contains invalid characters. Only alphanumeric, ., _, +, -, #, [, ], <, > are allowed"),
+		)
 	}
 	if err := CheckDenomString(v); err != nil {
 		return err
@@ -73,10 +135,13 @@ func ValidateDenom(i interface{}) error {
 func ValidateDecimalDifference(i interface{}) error {
 	v, ok := i.(uint32)
 	if !ok {
-		return fmt.Errorf("invalid parameter type: %T", i)
+		return invalidParamTypeError(FieldDecimalDifference, i)
 	}
-	if v > 18 {
-		return fmt.Errorf("decimal difference cannot be greater than 18")
+	if v > constants.MaxDecimalDifference {
+		return newValidationError(
+			sdkerrors.ErrInvalidRequest, FieldDecimalDifference, RuleDecimalDifferenceTooLarge, fmt.Sprintf("%d", v),
+			fmt.Errorf("decimal difference cannot be greater than %d", constants.MaxDecimalDifference),
+		).withLimits(0, constants.MaxDecimalDifference)
 	}
 	return nil
 }
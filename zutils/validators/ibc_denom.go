@@ -0,0 +1,140 @@
+package validators
+
+import (
+	"strings"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+
+	"zigchain/zutils/constants"
+)
+
+// ParseDenomTrace parses a full ICS-20 denom path, e.g. transfer/channel-0/transfer/channel-5/uatom,
+// into its trace and base denom and validates it with ValidateDenomTrace.
+//
+// A path without any port/channel hop (e.g. uatom) is returned as a denom without trace.
+func ParseDenomTrace(fullPath string) (transfertypes.Denom, error) {
+	denom := transfertypes.ExtractDenomFromPath(fullPath)
+
+	if err := ValidateDenomTrace(denom); err != nil {
+		return transfertypes.Denom{}, err
+	}
+
+	return denom, nil
+}
+
+// ValidateDenomTrace validates every hop of the trace with ValidatePort and ValidateChannel
+// and ensures the base denom is not blank.
+//
+// The base denom is not validated further as each chain defines its own rules for it.
+func ValidateDenomTrace(denom transfertypes.Denom) error {
+	if strings.TrimSpace(denom.Base) == "" {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidCoins,
+			FieldDenomTrace,
+			RuleDenomTraceEmptyBase,
+			denom.Path(),
+			"invalid denom trace: '%s' base denom cannot be blank",
+			denom.Path(),
+		)
+	}
+
+	for _, hop := range denom.Trace {
+		if err := ValidatePort(hop.PortId); err != nil {
+			return err
+		}
+		if err := ValidateChannel(hop.ChannelId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IBCDenomFromTrace returns the ibc/{hash} denom of the full denom path.
+// The hash is the uppercase hex SHA-256 of the path, as computed by ibc-go.
+// A path without any hop returns the base denom itself.
+func IBCDenomFromTrace(fullPath string) (string, error) {
+	denom, err := ParseDenomTrace(fullPath)
+	if err != nil {
+		return "", err
+	}
+
+	return denom.IBCDenom(), nil
+}
+
+// ibcHashHex is the validator of the hash of an ibc/{hash} denom: ibc-go encodes it in uppercase hex
+// and bank denoms are case-sensitive, so a lowercase hash is a different denom.
+var ibcHashHex = NewDigestValidator(DigestSHA256, HashEncodingHex).WithHexCase(HexCaseUpper)
+
+// ParseIBCDenomHash extracts the hash of an ibc/{hash} denom.
+//
+// Returns:
+//   - string: the hash, uppercase hex.
+//   - error: nil if the denom has the ibc prefix followed by a SHA-256 uppercase hex hash, otherwise a ValidationError.
+func ParseIBCDenomHash(ibcDenom string) (string, error) {
+	prefix := constants.IBCDenomPrefix + constants.DenomSeparator
+
+	hash, found := strings.CutPrefix(ibcDenom, prefix)
+	if !found || !ibcHashHex.IsValid(hash) {
+		return "", wrapValidationError(
+			sdkerrors.ErrInvalidCoins,
+			FieldDenom,
+			RuleDenomInvalidIBCHash,
+			ibcDenom,
+			"invalid ibc denom: '%s' has to be in the format %s{hash} where hash is a SHA-256 uppercase hex string",
+			ibcDenom,
+			prefix,
+		)
+	}
+
+	return hash, nil
+}
+
+// VerifyIBCDenom checks that the ibc/{hash} denom corresponds to the given full denom path.
+// The denom has to be exactly the one computed by ibc-go, a hash in another case is a different bank denom.
+//
+// Parameters:
+//   - ibcDenom: the denom received, e.g. ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2
+//   - fullPath: the expected trace and base denom, e.g. transfer/channel-0/uatom
+//
+// Returns:
+//   - error: nil if the denom matches the trace, otherwise a ValidationError.
+func VerifyIBCDenom(ibcDenom string, fullPath string) error {
+	denom, err := ParseDenomTrace(fullPath)
+	if err != nil {
+		return err
+	}
+
+	// a path without hops is not an IBC voucher, the denom has to be the base denom itself
+	if denom.IsNative() {
+		if ibcDenom != denom.Base {
+			return denomTraceMismatchError(ibcDenom, fullPath, denom.Base)
+		}
+		return nil
+	}
+
+	if _, err := ParseIBCDenomHash(ibcDenom); err != nil {
+		return err
+	}
+
+	if expected := denom.IBCDenom(); ibcDenom != expected {
+		return denomTraceMismatchError(ibcDenom, fullPath, expected)
+	}
+
+	return nil
+}
+
+// denomTraceMismatchError is returned when a denom does not match the trace it is supposed to represent.
+func denomTraceMismatchError(ibcDenom string, fullPath string, expected string) error {
+	return wrapValidationError(
+		sdkerrors.ErrInvalidCoins,
+		FieldDenom,
+		RuleDenomIBCHashMismatch,
+		ibcDenom,
+		"invalid ibc denom: '%s' does not match denom trace '%s', expected '%s'",
+		ibcDenom,
+		fullPath,
+		expected,
+	)
+}
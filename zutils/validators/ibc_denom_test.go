@@ -0,0 +1,203 @@
+package validators_test
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/validators"
+)
+
+// ATOM on a chain whose channel-0 points to the Cosmos Hub
+const atomIBCDenom = "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+
+func TestParseDenomTrace(t *testing.T) {
+	tests := []struct {
+		name     string
+		fullPath string
+		base     string
+		hops     int
+	}{
+		{
+			name:     "native denom",
+			fullPath: "uatom",
+			base:     "uatom",
+			hops:     0,
+		},
+		{
+			name:     "single hop",
+			fullPath: "transfer/channel-0/uatom",
+			base:     "uatom",
+			hops:     1,
+		},
+		{
+			name:     "multi hop",
+			fullPath: "transfer/channel-0/transfer/channel-141/uosmo",
+			base:     "uosmo",
+			hops:     2,
+		},
+		{
+			name:     "base denom with slashes",
+			fullPath: "transfer/channel-3/gamm/pool/1",
+			base:     "gamm/pool/1",
+			hops:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			denom, err := validators.ParseDenomTrace(tt.fullPath)
+			require.NoError(t, err)
+			require.Equal(t, tt.base, denom.Base)
+			require.Len(t, denom.Trace, tt.hops)
+			require.Equal(t, tt.fullPath, denom.Path())
+		})
+	}
+}
+
+func TestParseDenomTrace_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		fullPath string
+		rule     string
+	}{
+		{
+			name:     "empty path",
+			fullPath: "",
+			rule:     validators.RuleDenomTraceEmptyBase,
+		},
+		{
+			name:     "blank base denom",
+			fullPath: "transfer/channel-0/ ",
+			rule:     validators.RuleDenomTraceEmptyBase,
+		},
+		{
+			name:     "port with invalid characters",
+			fullPath: "trans@fer/channel-0/uatom",
+			rule:     validators.RulePortInvalidChars,
+		},
+		{
+			name:     "port too short",
+			fullPath: "t/channel-0/uatom",
+			rule:     validators.RulePortInvalidLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validators.ParseDenomTrace(tt.fullPath)
+			require.Error(t, err)
+
+			var vErr *validators.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, tt.rule, vErr.Rule)
+		})
+	}
+}
+
+func TestIBCDenomFromTrace(t *testing.T) {
+	ibcDenom, err := validators.IBCDenomFromTrace("transfer/channel-0/uatom")
+	require.NoError(t, err)
+	require.Equal(t, atomIBCDenom, ibcDenom)
+
+	multiHop := "transfer/channel-0/transfer/channel-141/uosmo"
+	ibcDenom, err = validators.IBCDenomFromTrace(multiHop)
+	require.NoError(t, err)
+	require.Equal(t, fmt.Sprintf("ibc/%X", sha256.Sum256([]byte(multiHop))), ibcDenom)
+
+	ibcDenom, err = validators.IBCDenomFromTrace("uzig")
+	require.NoError(t, err)
+	require.Equal(t, "uzig", ibcDenom)
+}
+
+func TestParseIBCDenomHash(t *testing.T) {
+	hash, err := validators.ParseIBCDenomHash(atomIBCDenom)
+	require.NoError(t, err)
+	require.Equal(t, strings.TrimPrefix(atomIBCDenom, "ibc/"), hash)
+
+	for _, denom := range []string{
+		"",
+		"ibc",
+		"ibc/",
+		"ibc/ABCD",
+		"uatom",
+		"ibc/" + strings.Repeat("Z", 64),
+		atomIBCDenom + "00",
+		// bank denoms are case-sensitive, only the uppercase hash computed by ibc-go is a voucher
+		strings.ToLower(atomIBCDenom),
+		strings.Replace(atomIBCDenom, "FB", "fb", 1),
+	} {
+		_, err := validators.ParseIBCDenomHash(denom)
+		require.Error(t, err, "expected error for %q", denom)
+		require.ErrorIs(t, err, sdkerrors.ErrInvalidCoins)
+
+		var vErr *validators.ValidationError
+		require.True(t, errors.As(err, &vErr))
+		require.Equal(t, validators.RuleDenomInvalidIBCHash, vErr.Rule)
+	}
+}
+
+func TestVerifyIBCDenom(t *testing.T) {
+	require.NoError(t, validators.VerifyIBCDenom(atomIBCDenom, "transfer/channel-0/uatom"))
+	require.NoError(t, validators.VerifyIBCDenom("uatom", "uatom"))
+
+	tests := []struct {
+		name     string
+		ibcDenom string
+		fullPath string
+		rule     string
+	}{
+		{
+			name:     "different channel",
+			ibcDenom: atomIBCDenom,
+			fullPath: "transfer/channel-1/uatom",
+			rule:     validators.RuleDenomIBCHashMismatch,
+		},
+		{
+			name:     "different base denom",
+			ibcDenom: atomIBCDenom,
+			fullPath: "transfer/channel-0/uosmo",
+			rule:     validators.RuleDenomIBCHashMismatch,
+		},
+		{
+			name:     "native path with ibc denom",
+			ibcDenom: atomIBCDenom,
+			fullPath: "uatom",
+			rule:     validators.RuleDenomIBCHashMismatch,
+		},
+		{
+			name:     "lowercase hash",
+			ibcDenom: strings.ToLower(atomIBCDenom),
+			fullPath: "transfer/channel-0/uatom",
+			rule:     validators.RuleDenomInvalidIBCHash,
+		},
+		{
+			name:     "malformed hash",
+			ibcDenom: "ibc/XYZ",
+			fullPath: "transfer/channel-0/uatom",
+			rule:     validators.RuleDenomInvalidIBCHash,
+		},
+		{
+			name:     "invalid trace",
+			ibcDenom: atomIBCDenom,
+			fullPath: "p/channel-0/uatom",
+			rule:     validators.RulePortInvalidLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validators.VerifyIBCDenom(tt.ibcDenom, tt.fullPath)
+			require.Error(t, err)
+
+			var vErr *validators.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, tt.rule, vErr.Rule)
+		})
+	}
+}
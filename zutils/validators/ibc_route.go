@@ -0,0 +1,151 @@
+package validators
+
+import (
+	"fmt"
+
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+)
+
+// IBCRoute binds the identifiers of one end of an IBC channel, e.g. the tokenwrapper params.
+type IBCRoute struct {
+	ClientID     string
+	ConnectionID string
+	Port         string
+	Channel      string
+
+	// CounterpartyPort and CounterpartyChannel identify the other end of the channel.
+	// They are optional, but have to be set together.
+	CounterpartyPort    string
+	CounterpartyChannel string
+	// CounterpartyConnectionID is the connection on the other chain, optional unless the route is used for forwarding.
+	CounterpartyConnectionID string
+}
+
+// Validate checks every field of the route and their consistency, see ValidateIBCRoute.
+func (r IBCRoute) Validate() error {
+	return ValidateIBCRoute(r)
+}
+
+// ValidateForwarding checks the route like Validate and that the counterparty is known, see ValidateIBCForwardingRoute.
+func (r IBCRoute) ValidateForwarding() error {
+	return ValidateIBCForwardingRoute(r)
+}
+
+// ValidateIBCRoute validates an IBCRoute or *IBCRoute.
+//
+// It performs the following checks:
+// 1. ClientID passes ValidateClientId.
+// 2. ConnectionID passes ValidateConnectionId.
+// 3. Port and Channel pass ValidatePort and ValidateChannel.
+// 4. CounterpartyPort and CounterpartyChannel are either both empty or both set,
+// and then pass ValidatePort and ValidateChannel.
+// 5. The counterparty port and channel are not the same pair as Port and Channel.
+// 6. CounterpartyConnectionID, if set, passes ValidateConnectionId.
+//
+// Every violation is reported, as ValidationErrors, with the route field name in ValidationError.Field.
+func ValidateIBCRoute(i interface{}) error {
+	route, err := ibcRouteOf(i)
+	if err != nil {
+		return err
+	}
+	return validateIBCRoute(route, false)
+}
+
+// ValidateIBCForwardingRoute validates an IBCRoute or *IBCRoute used to forward packets to the counterparty.
+// On top of the ValidateIBCRoute checks, CounterpartyChannel and CounterpartyConnectionID cannot be empty.
+func ValidateIBCForwardingRoute(i interface{}) error {
+	route, err := ibcRouteOf(i)
+	if err != nil {
+		return err
+	}
+	return validateIBCRoute(route, true)
+}
+
+func ibcRouteOf(i interface{}) (IBCRoute, error) {
+	var route IBCRoute
+	switch v := i.(type) {
+	case IBCRoute:
+		route = v
+	case *IBCRoute:
+		if v == nil {
+			return IBCRoute{}, invalidParamTypeError("ibc_route", i)
+		}
+		route = *v
+	default:
+		return IBCRoute{}, invalidParamTypeError("ibc_route", i)
+	}
+	return route, nil
+}
+
+func validateIBCRoute(route IBCRoute, forwarding bool) error {
+	var errs ValidationErrors
+	errs = errs.add(ValidateClientId(route.ClientID))
+	errs = errs.add(ValidateConnectionId(route.ConnectionID))
+	errs = errs.add(ValidatePort(route.Port))
+	errs = errs.add(ValidateChannel(route.Channel))
+
+	switch {
+	case route.CounterpartyPort == "" && route.CounterpartyChannel == "":
+		if forwarding {
+			errs = errs.add(missingCounterpartyError(FieldCounterpartyChannel))
+		}
+	case route.CounterpartyPort == "" || route.CounterpartyChannel == "":
+		missing, set := FieldCounterpartyPort, FieldCounterpartyChannel
+		if route.CounterpartyChannel == "" {
+			missing, set = set, missing
+		}
+		errs = errs.add(newValidationError(
+			channeltypes.ErrInvalidCounterparty,
+			missing,
+			RuleIBCRouteIncompleteCounterparty,
+			"",
+			fmt.Errorf("%w: %s cannot be empty when %s is set", channeltypes.ErrInvalidCounterparty, missing, set),
+		))
+	default:
+		errs = errs.add(withField(ValidatePort(route.CounterpartyPort), FieldCounterpartyPort))
+		errs = errs.add(withField(ValidateChannel(route.CounterpartyChannel), FieldCounterpartyChannel))
+
+		if route.CounterpartyPort == route.Port && route.CounterpartyChannel == route.Channel {
+			errs = errs.add(newValidationError(
+				channeltypes.ErrInvalidCounterparty,
+				FieldCounterpartyChannel,
+				RuleIBCRouteSameEndpoint,
+				route.CounterpartyChannel,
+				fmt.Errorf(
+					"%w: %s/%s cannot be both ends of the channel",
+					channeltypes.ErrInvalidCounterparty,
+					route.Port,
+					route.Channel,
+				),
+			))
+		}
+	}
+
+	switch {
+	case route.CounterpartyConnectionID != "":
+		errs = errs.add(withField(ValidateConnectionId(route.CounterpartyConnectionID), FieldCounterpartyConnID))
+	case forwarding:
+		errs = errs.add(missingCounterpartyError(FieldCounterpartyConnID))
+	}
+
+	return errs.errOrNil()
+}
+
+// missingCounterpartyError reports a counterparty field required to forward packets.
+func missingCounterpartyError(field string) error {
+	return newValidationError(
+		channeltypes.ErrInvalidCounterparty,
+		field,
+		RuleIBCRouteMissingCounterparty,
+		"",
+		fmt.Errorf("%w: %s cannot be empty when forwarding", channeltypes.ErrInvalidCounterparty, field),
+	)
+}
+
+// withField sets the field of a ValidationError, used when a check is reused for another field.
+func withField(err error, field string) error {
+	if vErr, ok := err.(*ValidationError); ok {
+		vErr.Field = field
+	}
+	return err
+}
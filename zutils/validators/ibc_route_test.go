@@ -0,0 +1,223 @@
+package validators_test
+
+import (
+	"errors"
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	channeltypes "github.com/cosmos/ibc-go/v10/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/validators"
+)
+
+func validRoute() validators.IBCRoute {
+	return validators.IBCRoute{
+		ClientID:            "07-tendermint-0",
+		ConnectionID:        "connection-0",
+		Port:                "transfer",
+		Channel:             "channel-0",
+		CounterpartyPort:    "transfer",
+		CounterpartyChannel: "channel-12",
+
+		CounterpartyConnectionID: "connection-3",
+	}
+}
+
+func TestValidateIBCRoute_Valid(t *testing.T) {
+	route := validRoute()
+	require.NoError(t, route.Validate())
+	require.NoError(t, validators.ValidateIBCRoute(&route))
+
+	// the counterparty is optional
+	route.CounterpartyPort = ""
+	route.CounterpartyChannel = ""
+	route.CounterpartyConnectionID = ""
+	require.NoError(t, route.Validate())
+
+	// the same channel id is allowed on another port
+	route = validRoute()
+	route.CounterpartyChannel = "channel-1"
+	route.Channel = "channel-1"
+	route.CounterpartyPort = "wasm.zig1contract"
+	require.NoError(t, route.Validate())
+}
+
+func TestValidateIBCRoute_Invalid(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		modify func(r *validators.IBCRoute)
+		fields []string
+		rules  []string
+	}{
+		{
+			desc:   "invalid client",
+			modify: func(r *validators.IBCRoute) { r.ClientID = "" },
+			fields: []string{validators.FieldClientID},
+			rules:  []string{validators.RuleClientIDEmpty},
+		},
+		{
+			desc:   "invalid connection",
+			modify: func(r *validators.IBCRoute) { r.ConnectionID = "conn" },
+			fields: []string{validators.FieldConnectionID},
+			rules:  []string{validators.RuleConnectionIDInvalidFormat},
+		},
+		{
+			desc:   "counterparty channel missing",
+			modify: func(r *validators.IBCRoute) { r.CounterpartyChannel = "" },
+			fields: []string{validators.FieldCounterpartyChannel},
+			rules:  []string{validators.RuleIBCRouteIncompleteCounterparty},
+		},
+		{
+			desc:   "counterparty port missing",
+			modify: func(r *validators.IBCRoute) { r.CounterpartyPort = "" },
+			fields: []string{validators.FieldCounterpartyPort},
+			rules:  []string{validators.RuleIBCRouteIncompleteCounterparty},
+		},
+		{
+			desc:   "invalid counterparty channel",
+			modify: func(r *validators.IBCRoute) { r.CounterpartyChannel = "chan" },
+			fields: []string{validators.FieldCounterpartyChannel},
+			rules:  []string{validators.RuleChannelInvalidFormat},
+		},
+		{
+			desc:   "same port and channel on both ends",
+			modify: func(r *validators.IBCRoute) { r.CounterpartyChannel = r.Channel },
+			fields: []string{validators.FieldCounterpartyChannel},
+			rules:  []string{validators.RuleIBCRouteSameEndpoint},
+		},
+		{
+			desc:   "invalid counterparty connection",
+			modify: func(r *validators.IBCRoute) { r.CounterpartyConnectionID = "conn" },
+			fields: []string{validators.FieldCounterpartyConnID},
+			rules:  []string{validators.RuleConnectionIDInvalidFormat},
+		},
+		{
+			desc: "every violation is reported",
+			modify: func(r *validators.IBCRoute) {
+				*r = validators.IBCRoute{CounterpartyPort: "a"}
+			},
+			fields: []string{
+				validators.FieldClientID,
+				validators.FieldConnectionID,
+				validators.FieldPort,
+				validators.FieldChannel,
+				validators.FieldCounterpartyChannel,
+			},
+			rules: []string{
+				validators.RuleClientIDEmpty,
+				validators.RuleConnectionIDEmpty,
+				validators.RulePortEmpty,
+				validators.RuleChannelEmpty,
+				validators.RuleIBCRouteIncompleteCounterparty,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			route := validRoute()
+			tc.modify(&route)
+
+			err := route.Validate()
+			require.Error(t, err)
+
+			var vErrs validators.ValidationErrors
+			require.True(t, errors.As(err, &vErrs))
+			require.Len(t, vErrs, len(tc.rules))
+			for i := range vErrs {
+				require.Equal(t, tc.fields[i], vErrs[i].Field)
+				require.Equal(t, tc.rules[i], vErrs[i].Rule)
+			}
+		})
+	}
+}
+
+func TestValidateIBCForwardingRoute(t *testing.T) {
+	route := validRoute()
+	require.NoError(t, route.ValidateForwarding())
+	require.NoError(t, validators.ValidateIBCForwardingRoute(&route))
+
+	testCases := []struct {
+		desc   string
+		modify func(r *validators.IBCRoute)
+		fields []string
+		rules  []string
+	}{
+		{
+			desc:   "counterparty channel missing",
+			modify: func(r *validators.IBCRoute) { r.CounterpartyPort, r.CounterpartyChannel = "", "" },
+			fields: []string{validators.FieldCounterpartyChannel},
+			rules:  []string{validators.RuleIBCRouteMissingCounterparty},
+		},
+		{
+			desc:   "counterparty connection missing",
+			modify: func(r *validators.IBCRoute) { r.CounterpartyConnectionID = "" },
+			fields: []string{validators.FieldCounterpartyConnID},
+			rules:  []string{validators.RuleIBCRouteMissingCounterparty},
+		},
+		{
+			desc: "no counterparty",
+			modify: func(r *validators.IBCRoute) {
+				r.CounterpartyPort, r.CounterpartyChannel, r.CounterpartyConnectionID = "", "", ""
+			},
+			fields: []string{validators.FieldCounterpartyChannel, validators.FieldCounterpartyConnID},
+			rules:  []string{validators.RuleIBCRouteMissingCounterparty, validators.RuleIBCRouteMissingCounterparty},
+		},
+		{
+			desc:   "same port and channel on both ends",
+			modify: func(r *validators.IBCRoute) { r.CounterpartyChannel = r.Channel },
+			fields: []string{validators.FieldCounterpartyChannel},
+			rules:  []string{validators.RuleIBCRouteSameEndpoint},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			route := validRoute()
+			tc.modify(&route)
+			// every case is a valid route, only forwarding requires more
+			if tc.rules[0] == validators.RuleIBCRouteMissingCounterparty {
+				require.NoError(t, route.Validate())
+			}
+
+			err := route.ValidateForwarding()
+			require.ErrorIs(t, err, channeltypes.ErrInvalidCounterparty)
+
+			var vErrs validators.ValidationErrors
+			require.True(t, errors.As(err, &vErrs))
+			require.Len(t, vErrs, len(tc.rules))
+			for i := range vErrs {
+				require.Equal(t, tc.fields[i], vErrs[i].Field)
+				require.Equal(t, tc.rules[i], vErrs[i].Rule)
+			}
+		})
+	}
+}
+
+func TestValidateIBCRoute_Messages(t *testing.T) {
+	route := validRoute()
+	route.Channel = ""
+	route.CounterpartyPort = ""
+
+	err := route.Validate()
+	require.ErrorIs(t, err, channeltypes.ErrInvalidChannelIdentifier)
+	require.ErrorIs(t, err, channeltypes.ErrInvalidCounterparty)
+	require.Equal(
+		t,
+		"invalid channel identifier: channel cannot be empty; "+
+			"invalid counterparty channel: counterparty_port cannot be empty when counterparty_channel is set",
+		err.Error(),
+	)
+}
+
+func TestValidateIBCRoute_InvalidType(t *testing.T) {
+	err := validators.ValidateIBCRoute("transfer/channel-0")
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidType)
+
+	err = validators.ValidateIBCRoute((*validators.IBCRoute)(nil))
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidType)
+
+	err = validators.ValidateIBCForwardingRoute((*validators.IBCRoute)(nil))
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidType)
+}
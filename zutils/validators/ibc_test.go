@@ -476,3 +476,48 @@ func TestValidateDenom_CheckDenomString(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConnectionId(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      interface{}
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "valid connection id",
+			id:      "connection-0",
+			wantErr: false,
+		},
+		{
+			name:    "empty connection id",
+			id:      "",
+			wantErr: true,
+			errMsg:  "invalid connection identifier: connection ID cannot be empty",
+		},
+		{
+			name:    "invalid format",
+			id:      "channel-0",
+			wantErr: true,
+			errMsg:  "invalid connection identifier: invalid connection ID format",
+		},
+		{
+			name:    "invalid type",
+			id:      0,
+			wantErr: true,
+			errMsg:  "invalid parameter type: int",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validators.ValidateConnectionId(tt.id)
+			if tt.wantErr {
+				require.Error(t, err)
+				require.Equal(t, tt.errMsg, err.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
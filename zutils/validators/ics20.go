@@ -0,0 +1,216 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	sdkmath "cosmossdk.io/math"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+)
+
+// PacketDirection tells which end of an ICS-20 transfer is on this chain.
+type PacketDirection int
+
+const (
+	// PacketIncoming is a packet received from another chain, the receiver is local (OnRecvPacket).
+	PacketIncoming PacketDirection = iota
+	// PacketOutgoing is a packet sent by this chain, the sender is local (OnAcknowledgementPacket, OnTimeoutPacket).
+	PacketOutgoing
+)
+
+// PacketDataOptions configures ValidateFungibleTokenPacketData.
+type PacketDataOptions struct {
+	// Direction tells which of sender and receiver is a local address.
+	Direction PacketDirection
+	// MaxAmount is the highest accepted amount, a nil Int means no limit.
+	MaxAmount sdkmath.Int
+	// MaxMemoLength is the longest accepted memo in bytes, 0 means transfertypes.MaximumMemoLength.
+	MaxMemoLength int
+	// RequireJSONMemo rejects non-empty memos that are not a JSON object.
+	// Without it plain text memos are accepted, but a memo starting with '{' has to be a valid JSON object.
+	RequireJSONMemo bool
+}
+
+// ValidateFungibleTokenPacketData validates decoded ICS-20 packet data end-to-end.
+//
+// It performs the following checks:
+// 1. The local address (receiver of incoming, sender of outgoing packets) passes AddressCheck.
+// 2. The foreign address passes ForeignAddressCheck.
+// 3. The amount is a positive integer string not greater than opts.MaxAmount.
+// 4. The denom is a valid denom path, see ParseDenomTrace.
+// 5. The memo is not longer than the limit and, if it looks like JSON, is a JSON object.
+//
+// Every violation is reported, as ValidationErrors.
+func ValidateFungibleTokenPacketData(data transfertypes.FungibleTokenPacketData, opts PacketDataOptions) error {
+	var errs ValidationErrors
+
+	if opts.Direction == PacketIncoming {
+		errs = errs.add(AddressCheck(FieldReceiver, data.Receiver))
+		errs = errs.add(ForeignAddressCheck(FieldSender, data.Sender))
+	} else {
+		errs = errs.add(AddressCheck(FieldSender, data.Sender))
+		errs = errs.add(ForeignAddressCheck(FieldReceiver, data.Receiver))
+	}
+
+	errs = errs.add(checkPacketAmount(data.Amount, opts.MaxAmount))
+
+	_, err := ParseDenomTrace(data.Denom)
+	errs = errs.add(err)
+
+	errs = errs.add(checkPacketMemo(data.Memo, opts))
+
+	return errs.errOrNil()
+}
+
+// ForeignAddressCheck validates an address of another chain: a bech32 address with any prefix.
+//
+// It performs the following checks:
+// 1. Ensures the address is not empty and not longer than transfertypes.MaximumReceiverLength.
+// 2. Verifies that the address is valid bech32 (charset and checksum).
+// 3. Checks that the decoded bytes are not empty and not longer than address.MaxAddrLen.
+func ForeignAddressCheck(field string, addr string) error {
+	if strings.TrimSpace(addr) == "" {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			field,
+			RuleAddressEmpty,
+			addr,
+			"%s address: cannot be empty",
+			field,
+		)
+	}
+
+	if len(addr) > transfertypes.MaximumReceiverLength {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			field,
+			RuleAddressTooLong,
+			addr,
+			"%s address: is %d characters long, max is %d",
+			field,
+			len(addr),
+			transfertypes.MaximumReceiverLength,
+		).withLimits(1, transfertypes.MaximumReceiverLength)
+	}
+
+	_, bz, err := bech32.DecodeAndConvert(addr)
+	if err != nil {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			field,
+			RuleAddressInvalidBech32,
+			addr,
+			"%s address: '%s' (%s)",
+			field,
+			addr,
+			err,
+		)
+	}
+
+	if len(bz) == 0 || len(bz) > address.MaxAddrLen {
+		rule := RuleAddressEmptyBytes
+		if len(bz) > 0 {
+			rule = RuleAddressTooLong
+		}
+		return wrapValidationError(
+			sdkerrors.ErrInvalidAddress,
+			field,
+			rule,
+			addr,
+			"%s address: '%s' is %d bytes long, it has to be between 1 and %d",
+			field,
+			addr,
+			len(bz),
+			address.MaxAddrLen,
+		).withLimits(1, address.MaxAddrLen)
+	}
+
+	return nil
+}
+
+// checkPacketAmount checks that the ICS-20 amount string is a positive integer not greater than maxAmount.
+func checkPacketAmount(amount string, maxAmount sdkmath.Int) error {
+	value, ok := sdkmath.NewIntFromString(amount)
+	if !ok {
+		return wrapValidationError(
+			transfertypes.ErrInvalidAmount,
+			FieldAmount,
+			RuleAmountInvalidFormat,
+			amount,
+			"packet amount: '%s' is not an integer",
+			amount,
+		)
+	}
+
+	if !value.IsPositive() {
+		return wrapValidationError(
+			transfertypes.ErrInvalidAmount,
+			FieldAmount,
+			RuleAmountNotPositive,
+			amount,
+			"packet amount: %s has to be positive",
+			amount,
+		)
+	}
+
+	if !maxAmount.IsNil() && value.GT(maxAmount) {
+		return wrapValidationError(
+			transfertypes.ErrInvalidAmount,
+			FieldAmount,
+			RuleAmountTooLarge,
+			amount,
+			"packet amount: %s cannot be greater than %s",
+			amount,
+			maxAmount,
+		)
+	}
+
+	return nil
+}
+
+// checkPacketMemo checks the memo length and, when it is meant to be JSON, that it is a JSON object.
+func checkPacketMemo(memo string, opts PacketDataOptions) error {
+	maxLength := opts.MaxMemoLength
+	if maxLength == 0 {
+		maxLength = transfertypes.MaximumMemoLength
+	}
+
+	if len(memo) > maxLength {
+		return wrapValidationError(
+			transfertypes.ErrInvalidMemo,
+			FieldMemo,
+			RuleMemoTooLong,
+			"",
+			"packet memo: is %d bytes long, max is %d",
+			len(memo),
+			maxLength,
+		).withLimits(0, maxLength)
+	}
+
+	trimmed := strings.TrimSpace(memo)
+	if trimmed == "" || (!opts.RequireJSONMemo && !strings.HasPrefix(trimmed, "{")) {
+		return nil
+	}
+
+	var object map[string]json.RawMessage
+	err := json.Unmarshal([]byte(trimmed), &object)
+	if err == nil && object == nil {
+		err = fmt.Errorf("got %s", trimmed)
+	}
+	if err != nil {
+		return wrapValidationError(
+			transfertypes.ErrInvalidMemo,
+			FieldMemo,
+			RuleMemoInvalidJSON,
+			memo,
+			"packet memo: has to be a JSON object (%s)",
+			err,
+		)
+	}
+
+	return nil
+}
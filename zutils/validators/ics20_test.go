@@ -0,0 +1,195 @@
+package validators_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	transfertypes "github.com/cosmos/ibc-go/v10/modules/apps/transfer/types"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/testutil/sample"
+	"zigchain/zutils/validators"
+)
+
+func validIncomingPacket(t *testing.T) transfertypes.FungibleTokenPacketData {
+	return transfertypes.NewFungibleTokenPacketData(
+		"transfer/channel-0/uatom",
+		"1000",
+		mustBech32(t, "cosmos", bytes.Repeat([]byte{0x01}, 20)),
+		sample.AccAddress(),
+		"",
+	)
+}
+
+func TestValidateFungibleTokenPacketData_Valid(t *testing.T) {
+	data := validIncomingPacket(t)
+	require.NoError(t, validators.ValidateFungibleTokenPacketData(data, validators.PacketDataOptions{}))
+
+	// outgoing packets have the local address as sender
+	data.Sender, data.Receiver = data.Receiver, data.Sender
+	require.NoError(t, validators.ValidateFungibleTokenPacketData(data, validators.PacketDataOptions{Direction: validators.PacketOutgoing}))
+
+	// plain text and JSON object memos
+	for _, memo := range []string{"thanks!", `{"forward":{"receiver":"x"}}`, `  {}  `} {
+		data := validIncomingPacket(t)
+		data.Memo = memo
+		require.NoError(t, validators.ValidateFungibleTokenPacketData(data, validators.PacketDataOptions{}), memo)
+	}
+
+	// the amount can be at the limit
+	require.NoError(t, validators.ValidateFungibleTokenPacketData(
+		validIncomingPacket(t),
+		validators.PacketDataOptions{MaxAmount: sdkmath.NewInt(1000)},
+	))
+}
+
+func TestValidateFungibleTokenPacketData_Invalid(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		modify func(d *transfertypes.FungibleTokenPacketData)
+		opts   validators.PacketDataOptions
+		field  string
+		rule   string
+		code   error
+	}{
+		{
+			desc:   "foreign receiver on incoming packet",
+			modify: func(d *transfertypes.FungibleTokenPacketData) { d.Receiver = d.Sender },
+			field:  validators.FieldReceiver,
+			rule:   validators.RuleAddressInvalidPrefix,
+			code:   sdkerrors.ErrInvalidAddress,
+		},
+		{
+			desc:   "sender not bech32",
+			modify: func(d *transfertypes.FungibleTokenPacketData) { d.Sender = "0x1234" },
+			field:  validators.FieldSender,
+			rule:   validators.RuleAddressInvalidBech32,
+			code:   sdkerrors.ErrInvalidAddress,
+		},
+		{
+			desc:   "blank sender",
+			modify: func(d *transfertypes.FungibleTokenPacketData) { d.Sender = "  " },
+			field:  validators.FieldSender,
+			rule:   validators.RuleAddressEmpty,
+			code:   sdkerrors.ErrInvalidAddress,
+		},
+		{
+			desc: "sender too long",
+			modify: func(d *transfertypes.FungibleTokenPacketData) {
+				d.Sender = strings.Repeat("a", transfertypes.MaximumReceiverLength+1)
+			},
+			field: validators.FieldSender,
+			rule:  validators.RuleAddressTooLong,
+			code:  sdkerrors.ErrInvalidAddress,
+		},
+		{
+			desc:   "amount not an integer",
+			modify: func(d *transfertypes.FungibleTokenPacketData) { d.Amount = "1.5" },
+			field:  validators.FieldAmount,
+			rule:   validators.RuleAmountInvalidFormat,
+			code:   transfertypes.ErrInvalidAmount,
+		},
+		{
+			desc:   "zero amount",
+			modify: func(d *transfertypes.FungibleTokenPacketData) { d.Amount = "0" },
+			field:  validators.FieldAmount,
+			rule:   validators.RuleAmountNotPositive,
+			code:   transfertypes.ErrInvalidAmount,
+		},
+		{
+			desc:   "amount above limit",
+			modify: func(d *transfertypes.FungibleTokenPacketData) { d.Amount = "1001" },
+			opts:   validators.PacketDataOptions{MaxAmount: sdkmath.NewInt(1000)},
+			field:  validators.FieldAmount,
+			rule:   validators.RuleAmountTooLarge,
+			code:   transfertypes.ErrInvalidAmount,
+		},
+		{
+			desc:   "invalid denom trace",
+			modify: func(d *transfertypes.FungibleTokenPacketData) { d.Denom = "transfer/channel-0/ " },
+			field:  validators.FieldDenomTrace,
+			rule:   validators.RuleDenomTraceEmptyBase,
+			code:   sdkerrors.ErrInvalidCoins,
+		},
+		{
+			desc:   "memo too long",
+			modify: func(d *transfertypes.FungibleTokenPacketData) { d.Memo = "12345" },
+			opts:   validators.PacketDataOptions{MaxMemoLength: 4},
+			field:  validators.FieldMemo,
+			rule:   validators.RuleMemoTooLong,
+			code:   transfertypes.ErrInvalidMemo,
+		},
+		{
+			desc:   "broken JSON memo",
+			modify: func(d *transfertypes.FungibleTokenPacketData) { d.Memo = `{"forward":` },
+			field:  validators.FieldMemo,
+			rule:   validators.RuleMemoInvalidJSON,
+			code:   transfertypes.ErrInvalidMemo,
+		},
+		{
+			desc:   "plain text memo when JSON is required",
+			modify: func(d *transfertypes.FungibleTokenPacketData) { d.Memo = "hello" },
+			opts:   validators.PacketDataOptions{RequireJSONMemo: true},
+			field:  validators.FieldMemo,
+			rule:   validators.RuleMemoInvalidJSON,
+			code:   transfertypes.ErrInvalidMemo,
+		},
+		{
+			desc:   "null memo when JSON is required",
+			modify: func(d *transfertypes.FungibleTokenPacketData) { d.Memo = "null" },
+			opts:   validators.PacketDataOptions{RequireJSONMemo: true},
+			field:  validators.FieldMemo,
+			rule:   validators.RuleMemoInvalidJSON,
+			code:   transfertypes.ErrInvalidMemo,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			data := validIncomingPacket(t)
+			tc.modify(&data)
+
+			err := validators.ValidateFungibleTokenPacketData(data, tc.opts)
+			require.ErrorIs(t, err, tc.code)
+
+			var vErrs validators.ValidationErrors
+			require.True(t, errors.As(err, &vErrs))
+			require.Len(t, vErrs, 1)
+			require.Equal(t, tc.field, vErrs[0].Field)
+			require.Equal(t, tc.rule, vErrs[0].Rule)
+		})
+	}
+}
+
+func TestValidateFungibleTokenPacketData_AllErrors(t *testing.T) {
+	data := transfertypes.NewFungibleTokenPacketData("", "abc", "", "", "{")
+
+	err := validators.ValidateFungibleTokenPacketData(data, validators.PacketDataOptions{})
+
+	var vErrs validators.ValidationErrors
+	require.True(t, errors.As(err, &vErrs))
+	require.Len(t, vErrs, 5)
+	require.Equal(
+		t,
+		"receiver address: cannot be empty: invalid address; "+
+			"sender address: cannot be empty: invalid address; "+
+			"packet amount: 'abc' is not an integer: invalid token amount; "+
+			"invalid denom trace: '' base denom cannot be blank: invalid coins; "+
+			"packet memo: has to be a JSON object (unexpected end of JSON input): invalid memo",
+		err.Error(),
+	)
+}
+
+func TestForeignAddressCheck(t *testing.T) {
+	require.NoError(t, validators.ForeignAddressCheck("sender", mustBech32(t, "osmo", bytes.Repeat([]byte{0x01}, 20))))
+	require.NoError(t, validators.ForeignAddressCheck("sender", mustBech32(t, "zig", bytes.Repeat([]byte{0x01}, 32))))
+
+	err := validators.ForeignAddressCheck("sender", mustBech32(t, "osmo", nil))
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleAddressEmptyBytes, vErr.Rule)
+}
@@ -0,0 +1,339 @@
+package validators
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+	"unicode"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	"zigchain/zutils/constants"
+)
+
+// metadataURISchemes are the URI schemes accepted in the metadata of a denom.
+var metadataURISchemes = []string{"https", IPFSScheme, ArweaveScheme}
+
+// ValidateDenomMetadata validates the bank metadata of a token, e.g. set for a factory denom.
+//
+// It performs the following checks:
+// 1. The base denom passes CheckDenomString, or ParseFactoryDenom for factory denoms.
+// 2. The first denom unit is the base denom with exponent 0, the exponents are strictly increasing
+// and not greater than constants.MaxDecimalDifference, and no denom or alias is used twice.
+// 3. The display denom is one of the denom units.
+// 4. The name and the symbol are not empty and not too long; the name is printable text without
+// surrounding spaces, the symbol starts with a letter followed by letters, digits, '.', '-' or '_'.
+// 5. The URI, if set, is not longer than constants.MaxURILength and uses an https, ipfs or ar scheme;
+// ipfs and ar URIs pass ParseContentURI.
+// 6. The URI hash is empty or, when a URI is set, a SHA-256 hash encoded as hex or base64 (standard or URL,
+// padded or not) and not longer than constants.MaxURIHashLength. For ipfs URIs of raw CIDs it matches the CID digest.
+//
+// Every violation is reported, as ValidationErrors.
+func ValidateDenomMetadata(metadata banktypes.Metadata) error {
+	var errs ValidationErrors
+
+	if IsFactoryDenom(metadata.Base) {
+		_, err := ParseFactoryDenom(metadata.Base)
+		errs = errs.add(err)
+	} else {
+		errs = errs.add(CheckDenomString(metadata.Base))
+	}
+
+	errs = append(errs, checkDenomUnits(metadata.Base, metadata.DenomUnits)...)
+	errs = errs.add(checkMetadataDisplay(metadata.Display, metadata.DenomUnits))
+	errs = errs.add(checkMetadataName(metadata.Name))
+	errs = errs.add(checkMetadataSymbol(metadata.Symbol))
+	uriErr := checkMetadataURI(metadata.URI)
+	errs = errs.add(uriErr)
+	errs = errs.add(checkMetadataURIHash(metadata.URI, metadata.URIHash, uriErr == nil))
+
+	return errs.errOrNil()
+}
+
+// checkDenomUnits checks the denom units against the base denom, see ValidateDenomMetadata.
+func checkDenomUnits(base string, units []*banktypes.DenomUnit) ValidationErrors {
+	var errs ValidationErrors
+
+	if len(units) == 0 {
+		return errs.add(wrapValidationError(
+			sdkerrors.ErrInvalidCoins,
+			FieldDenomUnits,
+			RuleDenomUnitsEmpty,
+			"",
+			"denom metadata: denom units cannot be empty, the first unit has to be the base denom '%s'",
+			base,
+		))
+	}
+
+	if first := units[0]; first == nil || first.Denom != base || first.Exponent != 0 {
+		errs = errs.add(wrapValidationError(
+			sdkerrors.ErrInvalidCoins,
+			FieldDenomUnits,
+			RuleDenomUnitsInvalidBase,
+			first.GetDenom(),
+			"denom metadata: the first denom unit has to be the base denom '%s' with exponent 0",
+			base,
+		))
+	}
+
+	seen := make(map[string]bool)
+	for i, unit := range units {
+		if unit == nil {
+			continue
+		}
+
+		if i > 0 && units[i-1] != nil && unit.Exponent <= units[i-1].Exponent {
+			errs = errs.add(wrapValidationError(
+				sdkerrors.ErrInvalidCoins,
+				FieldDenomUnits,
+				RuleDenomUnitsNotSorted,
+				unit.Denom,
+				"denom metadata: exponent %d of '%s' has to be greater than exponent %d of '%s'",
+				unit.Exponent,
+				unit.Denom,
+				units[i-1].Exponent,
+				units[i-1].Denom,
+			))
+		}
+
+		if unit.Exponent > constants.MaxDecimalDifference {
+			errs = errs.add(wrapValidationError(
+				sdkerrors.ErrInvalidCoins,
+				FieldDenomUnits,
+				RuleDenomUnitsExponentTooLarge,
+				unit.Denom,
+				"denom metadata: exponent %d of '%s' cannot be greater than %d",
+				unit.Exponent,
+				unit.Denom,
+				constants.MaxDecimalDifference,
+			).withLimits(0, constants.MaxDecimalDifference))
+		}
+
+		for _, denom := range append([]string{unit.Denom}, unit.Aliases...) {
+			if i > 0 {
+				errs = errs.add(withField(CheckDenomString(denom), FieldDenomUnits))
+			}
+			if seen[denom] {
+				errs = errs.add(wrapValidationError(
+					sdkerrors.ErrInvalidCoins,
+					FieldDenomUnits,
+					RuleDenomUnitsDuplicateDenom,
+					denom,
+					"denom metadata: '%s' is used by more than one denom unit or alias",
+					denom,
+				))
+			}
+			seen[denom] = true
+		}
+	}
+
+	return errs
+}
+
+// checkMetadataDisplay checks that the display denom is one of the denom units.
+func checkMetadataDisplay(display string, units []*banktypes.DenomUnit) error {
+	for _, unit := range units {
+		if unit != nil && unit.Denom == display {
+			return nil
+		}
+	}
+
+	return wrapValidationError(
+		sdkerrors.ErrInvalidCoins,
+		FieldDisplay,
+		RuleDisplayNotFound,
+		display,
+		"denom metadata: display denom '%s' has to be one of the denom units",
+		display,
+	)
+}
+
+// checkMetadataName checks that the name is printable text of at most constants.MaxMetadataNameLength bytes.
+func checkMetadataName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldName,
+			RuleNameEmpty,
+			name,
+			"denom metadata: name cannot be empty",
+		)
+	}
+
+	if len(name) > constants.MaxMetadataNameLength {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldName,
+			RuleNameTooLong,
+			name,
+			"denom metadata: name is %d characters long, max is %d",
+			len(name),
+			constants.MaxMetadataNameLength,
+		).withLimits(1, constants.MaxMetadataNameLength)
+	}
+
+	if name != strings.TrimSpace(name) || strings.IndexFunc(name, func(r rune) bool { return !unicode.IsPrint(r) }) >= 0 {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldName,
+			RuleNameInvalidChars,
+			name,
+			"denom metadata: name '%s' has to be printable text without leading or trailing spaces",
+			name,
+		)
+	}
+
+	return nil
+}
+
+// checkMetadataSymbol checks that the symbol is a ticker of at most constants.MaxMetadataSymbolLength characters.
+func checkMetadataSymbol(symbol string) error {
+	if symbol == "" {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldSymbol,
+			RuleSymbolEmpty,
+			symbol,
+			"denom metadata: symbol cannot be empty",
+		)
+	}
+
+	if len(symbol) > constants.MaxMetadataSymbolLength {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldSymbol,
+			RuleSymbolTooLong,
+			symbol,
+			"denom metadata: symbol is %d characters long, max is %d",
+			len(symbol),
+			constants.MaxMetadataSymbolLength,
+		).withLimits(1, constants.MaxMetadataSymbolLength)
+	}
+
+	for i, c := range symbol {
+		letter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if letter || (i > 0 && ((c >= '0' && c <= '9') || c == '.' || c == '-' || c == '_')) {
+			continue
+		}
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldSymbol,
+			RuleSymbolInvalidChars,
+			symbol,
+			"denom metadata: symbol '%s' has to start with a letter followed by letters, digits, '.', '-' or '_'",
+			symbol,
+		)
+	}
+
+	return nil
+}
+
+// checkMetadataURI checks the length and the scheme of a non-empty URI.
+func checkMetadataURI(uri string) error {
+	if uri == "" {
+		return nil
+	}
+
+	if len(uri) > constants.MaxURILength {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldURI,
+			RuleURITooLong,
+			uri,
+			"denom metadata: uri is %d characters long, max is %d",
+			len(uri),
+			constants.MaxURILength,
+		).withLimits(0, constants.MaxURILength)
+	}
+
+	// IsURI does not handle the IDs of content-addressed URIs, they are parsed instead
+	if IsContentURI(uri) {
+		_, err := ParseContentURI(uri)
+		return err
+	}
+
+	parsed, err := url.Parse(uri)
+	if err != nil || !IsURI(uri) || parsed.Host == "" {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldURI,
+			RuleURIInvalidFormat,
+			uri,
+			"denom metadata: uri '%s' is not a valid absolute URI",
+			uri,
+		)
+	}
+
+	if !slices.Contains(metadataURISchemes, strings.ToLower(parsed.Scheme)) {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldURI,
+			RuleURISchemeNotAllowed,
+			uri,
+			"denom metadata: uri scheme '%s' is not allowed, use one of %s",
+			parsed.Scheme,
+			strings.Join(metadataURISchemes, ", "),
+		)
+	}
+
+	return nil
+}
+
+// checkMetadataURIHash checks that the URI hash is empty or a well-formed SHA-256 hash of the document at uri.
+//
+// For an ipfs uri of a raw SHA-256 CID the hash also has to match the CID digest, which is then the hash of
+// the document itself. The digest of a dag-pb CID (Qm... and the default bafy...) is the one of the root
+// protobuf block and cannot be compared, see VerifyDocumentHash to check such a document.
+// The comparison is skipped when the uri failed checkMetadataURI, so an oversized uri is never decoded.
+func checkMetadataURIHash(uri string, uriHash string, uriValid bool) error {
+	if uriHash == "" {
+		return nil
+	}
+
+	if uri == "" {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldURIHash,
+			RuleURIHashWithoutURI,
+			uriHash,
+			"denom metadata: uri hash cannot be set without a uri",
+		)
+	}
+
+	if len(uriHash) > constants.MaxURIHashLength {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldURIHash,
+			RuleURIHashTooLong,
+			uriHash,
+			"denom metadata: uri hash is %d characters long, max is %d",
+			len(uriHash),
+			constants.MaxURIHashLength,
+		).withLimits(0, constants.MaxURIHashLength)
+	}
+
+	if _, _, err := DetectHashEncoding(uriHash); err != nil {
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldURIHash,
+			RuleURIHashInvalidFormat,
+			uriHash,
+			"denom metadata: uri hash '%s' has to be a SHA-256 hash encoded as hex or base64",
+			uriHash,
+		)
+	}
+
+	// an invalid uri is reported by checkMetadataURI
+	if !uriValid || !IsContentURI(uri) {
+		return nil
+	}
+
+	content, err := ParseContentURI(uri)
+	if err == nil && content.Scheme == IPFSScheme && content.Path == "" &&
+		content.CID.Codec == CIDCodecRaw && content.CID.Multihash.IsSHA256() {
+		return content.CID.VerifyDigest(uriHash)
+	}
+
+	return nil
+}
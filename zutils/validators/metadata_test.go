@@ -0,0 +1,229 @@
+package validators_test
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/testutil/sample"
+	"zigchain/zutils/validators"
+)
+
+func validMetadata() banktypes.Metadata {
+	base := "factory/" + sample.AccAddress() + "/umoon"
+	return banktypes.Metadata{
+		Description: "Moon token",
+		DenomUnits: []*banktypes.DenomUnit{
+			{Denom: base, Exponent: 0, Aliases: []string{"micromoon"}},
+			{Denom: "moon", Exponent: 6},
+		},
+		Base:    base,
+		Display: "moon",
+		Name:    "Moon Token",
+		Symbol:  "MOON",
+		URI:     "https://example.com/moon.json",
+		URIHash: validators.SHA256HashOfURL("document"),
+	}
+}
+
+func TestValidateDenomMetadata_Valid(t *testing.T) {
+	require.NoError(t, validators.ValidateDenomMetadata(validMetadata()))
+
+	hash := sha256.Sum256([]byte("document"))
+	for _, uriHash := range []string{
+		"",
+		base64.StdEncoding.EncodeToString(hash[:]),
+		base64.RawStdEncoding.EncodeToString(hash[:]),
+		base64.URLEncoding.EncodeToString(hash[:]),
+		base64.RawURLEncoding.EncodeToString(hash[:]),
+	} {
+		metadata := validMetadata()
+		metadata.URIHash = uriHash
+		require.NoError(t, validators.ValidateDenomMetadata(metadata), uriHash)
+	}
+
+	// the uri is optional, ipfs is allowed
+	metadata := validMetadata()
+	metadata.URI = ""
+	metadata.URIHash = ""
+	require.NoError(t, validators.ValidateDenomMetadata(metadata))
+	metadata.URI = "ipfs://bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+	require.NoError(t, validators.ValidateDenomMetadata(metadata))
+
+	// native denoms with up to 18 decimals
+	require.NoError(t, validators.ValidateDenomMetadata(banktypes.Metadata{
+		DenomUnits: []*banktypes.DenomUnit{{Denom: "aevmos", Exponent: 0}, {Denom: "evmos", Exponent: 18}},
+		Base:       "aevmos",
+		Display:    "evmos",
+		Name:       "Evmos",
+		Symbol:     "EVMOS",
+	}))
+}
+
+func TestValidateDenomMetadata_Invalid(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		modify func(m *banktypes.Metadata)
+		rules  []string
+	}{
+		{
+			desc:   "invalid factory base",
+			modify: func(m *banktypes.Metadata) { m.Base = "factory/zig1invalid/umoon"; m.DenomUnits[0].Denom = m.Base },
+			rules:  []string{validators.RuleAddressInvalidBech32},
+		},
+		{
+			desc:   "no denom units",
+			modify: func(m *banktypes.Metadata) { m.DenomUnits = nil },
+			rules:  []string{validators.RuleDenomUnitsEmpty, validators.RuleDisplayNotFound},
+		},
+		{
+			desc:   "first unit is not the base",
+			modify: func(m *banktypes.Metadata) { m.DenomUnits[0], m.DenomUnits[1] = m.DenomUnits[1], m.DenomUnits[0] },
+			rules:  []string{validators.RuleDenomUnitsInvalidBase, validators.RuleDenomUnitsNotSorted},
+		},
+		{
+			desc: "exponents not increasing",
+			modify: func(m *banktypes.Metadata) {
+				m.DenomUnits = append(m.DenomUnits, &banktypes.DenomUnit{Denom: "kmoon", Exponent: 6})
+			},
+			rules: []string{validators.RuleDenomUnitsNotSorted},
+		},
+		{
+			desc:   "exponent above 18",
+			modify: func(m *banktypes.Metadata) { m.DenomUnits[1].Exponent = 19 },
+			rules:  []string{validators.RuleDenomUnitsExponentTooLarge},
+		},
+		{
+			desc:   "alias reused",
+			modify: func(m *banktypes.Metadata) { m.DenomUnits[1].Aliases = []string{"micromoon"} },
+			rules:  []string{validators.RuleDenomUnitsDuplicateDenom},
+		},
+		{
+			desc:   "invalid unit denom",
+			modify: func(m *banktypes.Metadata) { m.DenomUnits[1].Denom = "mo"; m.Display = "mo" },
+			rules:  []string{validators.RuleDenomTooShort},
+		},
+		{
+			desc:   "unknown display",
+			modify: func(m *banktypes.Metadata) { m.Display = "sun" },
+			rules:  []string{validators.RuleDisplayNotFound},
+		},
+		{
+			desc:   "empty name",
+			modify: func(m *banktypes.Metadata) { m.Name = "  " },
+			rules:  []string{validators.RuleNameEmpty},
+		},
+		{
+			desc:   "name too long",
+			modify: func(m *banktypes.Metadata) { m.Name = strings.Repeat("a", 65) },
+			rules:  []string{validators.RuleNameTooLong},
+		},
+		{
+			desc:   "name with trailing space",
+			modify: func(m *banktypes.Metadata) { m.Name = "Moon " },
+			rules:  []string{validators.RuleNameInvalidChars},
+		},
+		{
+			desc:   "name with control character",
+			modify: func(m *banktypes.Metadata) { m.Name = "Moon\nToken" },
+			rules:  []string{validators.RuleNameInvalidChars},
+		},
+		{
+			desc:   "empty symbol",
+			modify: func(m *banktypes.Metadata) { m.Symbol = "" },
+			rules:  []string{validators.RuleSymbolEmpty},
+		},
+		{
+			desc:   "symbol too long",
+			modify: func(m *banktypes.Metadata) { m.Symbol = strings.Repeat("A", 17) },
+			rules:  []string{validators.RuleSymbolTooLong},
+		},
+		{
+			desc:   "symbol starting with a digit",
+			modify: func(m *banktypes.Metadata) { m.Symbol = "1MOON" },
+			rules:  []string{validators.RuleSymbolInvalidChars},
+		},
+		{
+			desc:   "uri too long",
+			modify: func(m *banktypes.Metadata) { m.URI = "https://example.com/" + strings.Repeat("a", 240) },
+			rules:  []string{validators.RuleURITooLong},
+		},
+		{
+			desc:   "ipfs uri too long is not compared with the uri hash",
+			modify: func(m *banktypes.Metadata) { m.URI = "ipfs://z" + strings.Repeat("2", 300) },
+			rules:  []string{validators.RuleURITooLong},
+		},
+		{
+			desc:   "relative uri",
+			modify: func(m *banktypes.Metadata) { m.URI = "/moon.json" },
+			rules:  []string{validators.RuleURIInvalidFormat},
+		},
+		{
+			desc:   "http uri",
+			modify: func(m *banktypes.Metadata) { m.URI = "http://example.com/moon.json" },
+			rules:  []string{validators.RuleURISchemeNotAllowed},
+		},
+		{
+			desc:   "uri hash without uri",
+			modify: func(m *banktypes.Metadata) { m.URI = "" },
+			rules:  []string{validators.RuleURIHashWithoutURI},
+		},
+		{
+			desc:   "uri hash too long",
+			modify: func(m *banktypes.Metadata) { m.URIHash += "00" },
+			rules:  []string{validators.RuleURIHashTooLong},
+		},
+		{
+			desc:   "uri hash not hex",
+			modify: func(m *banktypes.Metadata) { m.URIHash = strings.Repeat("g", 64) },
+			rules:  []string{validators.RuleURIHashInvalidFormat},
+		},
+		{
+			desc: "uri hash of the wrong size",
+			modify: func(m *banktypes.Metadata) {
+				m.URIHash = base64.RawStdEncoding.EncodeToString(make([]byte, 20))
+			},
+			rules: []string{validators.RuleURIHashInvalidFormat},
+		},
+		{
+			desc:   "uri hash mixing base64 alphabets",
+			modify: func(m *banktypes.Metadata) { m.URIHash = "-" + strings.Repeat("A", 41) + "+" },
+			rules:  []string{validators.RuleURIHashInvalidFormat},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			metadata := validMetadata()
+			tc.modify(&metadata)
+
+			err := validators.ValidateDenomMetadata(metadata)
+			var errs validators.ValidationErrors
+			require.True(t, errors.As(err, &errs), "%v", err)
+
+			rules := make([]string, len(errs))
+			for i, e := range errs {
+				rules[i] = e.Rule
+			}
+			require.Equal(t, tc.rules, rules)
+		})
+	}
+}
+
+func TestValidateDenomMetadata_Message(t *testing.T) {
+	metadata := validMetadata()
+	metadata.DenomUnits[1].Exponent = 24
+
+	err := validators.ValidateDenomMetadata(metadata)
+	require.EqualError(t, err, "denom metadata: exponent 24 of 'moon' cannot be greater than 18: invalid coins")
+
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.FieldDenomUnits, vErr.Field)
+	require.Equal(t, 18, vErr.Max)
+}
@@ -0,0 +1,181 @@
+package validators
+
+import (
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+
+	"zigchain/zutils/constants"
+)
+
+// ModuleAddress returns the address of the module account with the given name,
+// the same address as authtypes.NewModuleAddress.
+func ModuleAddress(name string) sdk.AccAddress {
+	return address.Module(name)
+}
+
+// PoolAddress returns the address of the account holding the reserves of a dex pool, the same address
+// as the dex keeper derives: the module address of the dex module with the pool id string, e.g. zp123,
+// as derivation key.
+func PoolAddress(poolID PoolID) sdk.AccAddress {
+	return address.Module(constants.DexModuleName, []byte(poolID.String()))
+}
+
+// DefaultModuleAccounts are the module accounts registered by DefaultModuleAccountRegistry.
+var DefaultModuleAccounts = []string{
+	constants.FeeCollectorName,
+	constants.DistributionModuleName,
+	constants.BondedPoolName,
+	constants.NotBondedPoolName,
+	constants.GovModuleName,
+	constants.MintModuleName,
+	constants.TransferModuleName,
+	constants.DexModuleName,
+	constants.FactoryModuleName,
+	constants.TokenWrapperModuleName,
+}
+
+// ModuleAccountRegistry keeps track of module accounts and blocked addresses.
+// It is safe for concurrent use.
+type ModuleAccountRegistry struct {
+	mu      sync.RWMutex
+	modules map[string]string
+	blocked map[string]string
+}
+
+// NewModuleAccountRegistry creates an empty registry.
+func NewModuleAccountRegistry() *ModuleAccountRegistry {
+	return &ModuleAccountRegistry{
+		modules: make(map[string]string),
+		blocked: make(map[string]string),
+	}
+}
+
+// DefaultModuleAccountRegistry creates a registry with DefaultModuleAccounts registered.
+func DefaultModuleAccountRegistry() *ModuleAccountRegistry {
+	r := NewModuleAccountRegistry()
+	for _, name := range DefaultModuleAccounts {
+		r.RegisterModule(name)
+	}
+	return r
+}
+
+// defaultModuleAccountRegistry is the registry of a policy without Registry, built once and never modified.
+var defaultModuleAccountRegistry = sync.OnceValue(DefaultModuleAccountRegistry)
+
+// RegisterModule registers the module account with the given name and returns its address.
+func (r *ModuleAccountRegistry) RegisterModule(name string) sdk.AccAddress {
+	addr := ModuleAddress(name)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.modules[string(addr)] = name
+
+	return addr
+}
+
+// RegisterPool registers the account holding the reserves of a dex pool and returns its address.
+// The address is derived with PoolAddress and reported as a module account named after the pool id, e.g. zp123.
+func (r *ModuleAccountRegistry) RegisterPool(poolID PoolID) (sdk.AccAddress, error) {
+	if err := poolID.Validate(); err != nil {
+		return nil, err
+	}
+	addr := PoolAddress(poolID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.modules[string(addr)] = poolID.String()
+
+	return addr, nil
+}
+
+// Block adds an address that can never be used where a policy is applied, e.g. an operator address.
+func (r *ModuleAccountRegistry) Block(addr sdk.AccAddress, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.blocked[string(addr)] = reason
+}
+
+// ModuleName returns the name of the module account with the given address.
+func (r *ModuleAccountRegistry) ModuleName(addr sdk.AccAddress) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.modules[string(addr)]
+	return name, ok
+}
+
+// IsModuleAccount reports whether the address is a registered module account.
+func (r *ModuleAccountRegistry) IsModuleAccount(addr sdk.AccAddress) bool {
+	_, ok := r.ModuleName(addr)
+	return ok
+}
+
+// BlockedReason returns the reason an address was blocked for.
+func (r *ModuleAccountRegistry) BlockedReason(addr sdk.AccAddress) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	reason, ok := r.blocked[string(addr)]
+	return reason, ok
+}
+
+// AddressPolicy configures AddressCheckWithPolicy.
+type AddressPolicy struct {
+	// Registry holds the module accounts and blocked addresses, nil means DefaultModuleAccountRegistry,
+	// so the zero value policy still rejects the default module accounts.
+	Registry *ModuleAccountRegistry
+	// AllowModuleAccounts accepts every module account.
+	AllowModuleAccounts bool
+	// AllowedModules accepts the module accounts with these names, e.g. a recipient that can only be the fee collector.
+	AllowedModules []string
+}
+
+// AddressCheckWithPolicy validates an account address and applies the policy, e.g. for the recipient of a transfer.
+//
+// It performs the following checks:
+// 1. The address passes Bech32AddressCheck with AddressRoleAccount.
+// 2. The address is not blocked in the registry.
+// 3. The address is not a module account, unless the policy allows it.
+//
+// Blocked addresses and module accounts are reported with ErrAddressNotAllowed and the rules
+// RuleAddressBlocked and RuleAddressModuleAccount.
+func AddressCheckWithPolicy(field string, addr string, policy AddressPolicy) error {
+	bz, err := decodeBech32Address(field, addr, AddressRoleAccount)
+	if err != nil {
+		return err
+	}
+
+	registry := policy.Registry
+	if registry == nil {
+		registry = defaultModuleAccountRegistry()
+	}
+
+	if reason, ok := registry.BlockedReason(bz); ok {
+		return wrapValidationError(
+			ErrAddressNotAllowed,
+			field,
+			RuleAddressBlocked,
+			addr,
+			"%s address: '%s' is blocked (%s)",
+			field,
+			addr,
+			reason,
+		)
+	}
+
+	name, ok := registry.ModuleName(bz)
+	if !ok || policy.AllowModuleAccounts || toSet(policy.AllowedModules)[name] {
+		return nil
+	}
+
+	return wrapValidationError(
+		ErrAddressNotAllowed,
+		field,
+		RuleAddressModuleAccount,
+		addr,
+		"%s address: '%s' is the %s module account and cannot be used here",
+		field,
+		addr,
+		name,
+	)
+}
@@ -0,0 +1,149 @@
+package validators_test
+
+import (
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/testutil/sample"
+	"zigchain/zutils/constants"
+	"zigchain/zutils/validators"
+)
+
+func bech32Account(t *testing.T, addr sdk.AccAddress) string {
+	t.Helper()
+	return mustBech32(t, constants.AddressPrefix, addr)
+}
+
+func TestModuleAddress(t *testing.T) {
+	// the well known fee collector address, the same on every cosmos chain
+	expected, _, err := validators.ConvertBech32Prefix("cosmos17xpfvakm2amg962yls6f84z3kell8c5lserqta", constants.AddressPrefix)
+	require.NoError(t, err)
+	require.Equal(t, expected, bech32Account(t, validators.ModuleAddress(constants.FeeCollectorName)))
+}
+
+func TestPoolAddress(t *testing.T) {
+	// derived like the dex keeper does, from the dex module and the pool id string
+	require.Equal(t, sdk.AccAddress(address.Module(constants.DexModuleName, []byte("zp7"))), validators.PoolAddress(7))
+	require.Len(t, validators.PoolAddress(7), 32)
+	require.NotEqual(t, validators.PoolAddress(7), validators.PoolAddress(8))
+	require.NotEqual(t, validators.ModuleAddress(constants.DexModuleName), validators.PoolAddress(7))
+}
+
+func TestModuleAccountRegistry(t *testing.T) {
+	r := validators.DefaultModuleAccountRegistry()
+
+	for _, name := range validators.DefaultModuleAccounts {
+		got, ok := r.ModuleName(validators.ModuleAddress(name))
+		require.True(t, ok, name)
+		require.Equal(t, name, got)
+	}
+
+	poolAddr, err := r.RegisterPool(7)
+	require.NoError(t, err)
+	require.Equal(t, validators.PoolAddress(7), poolAddr)
+	name, ok := r.ModuleName(poolAddr)
+	require.True(t, ok)
+	require.Equal(t, "zp7", name)
+
+	_, err = r.RegisterPool(0)
+	require.Error(t, err)
+
+	user, err := sdk.AccAddressFromBech32(sample.AccAddress())
+	require.NoError(t, err)
+	require.False(t, r.IsModuleAccount(user))
+
+	_, ok = r.BlockedReason(user)
+	require.False(t, ok)
+	r.Block(user, "operator address")
+	reason, ok := r.BlockedReason(user)
+	require.True(t, ok)
+	require.Equal(t, "operator address", reason)
+
+	require.False(t, validators.NewModuleAccountRegistry().IsModuleAccount(validators.ModuleAddress(constants.GovModuleName)))
+}
+
+func TestAddressCheckWithPolicy(t *testing.T) {
+	r := validators.DefaultModuleAccountRegistry()
+	poolAddr, err := r.RegisterPool(1)
+	require.NoError(t, err)
+
+	_, operatorBz, err := bech32.DecodeAndConvert(sample.AccAddress())
+	require.NoError(t, err)
+	r.Block(operatorBz, "operator address")
+
+	user := sample.AccAddress()
+	feeCollector := bech32Account(t, validators.ModuleAddress(constants.FeeCollectorName))
+	pool := bech32Account(t, poolAddr)
+	operator := bech32Account(t, operatorBz)
+
+	testCases := []struct {
+		desc   string
+		addr   string
+		policy validators.AddressPolicy
+		rule   string
+	}{
+		{desc: "user", addr: user, policy: validators.AddressPolicy{Registry: r}},
+		{desc: "user without registry", addr: user, policy: validators.AddressPolicy{}},
+		{desc: "module account without registry", addr: feeCollector, policy: validators.AddressPolicy{}, rule: validators.RuleAddressModuleAccount},
+		{desc: "module account", addr: feeCollector, policy: validators.AddressPolicy{Registry: r}, rule: validators.RuleAddressModuleAccount},
+		{desc: "pool account", addr: pool, policy: validators.AddressPolicy{Registry: r}, rule: validators.RuleAddressModuleAccount},
+		{desc: "module accounts allowed", addr: pool, policy: validators.AddressPolicy{Registry: r, AllowModuleAccounts: true}},
+		{
+			desc:   "allowed module",
+			addr:   feeCollector,
+			policy: validators.AddressPolicy{Registry: r, AllowedModules: []string{constants.FeeCollectorName}},
+		},
+		{
+			desc:   "other module not allowed",
+			addr:   pool,
+			policy: validators.AddressPolicy{Registry: r, AllowedModules: []string{constants.FeeCollectorName}},
+			rule:   validators.RuleAddressModuleAccount,
+		},
+		{desc: "blocked", addr: operator, policy: validators.AddressPolicy{Registry: r, AllowModuleAccounts: true}, rule: validators.RuleAddressBlocked},
+		{desc: "invalid", addr: "zig1invalid", policy: validators.AddressPolicy{Registry: r}, rule: validators.RuleAddressInvalidBech32},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := validators.AddressCheckWithPolicy("recipient", tc.addr, tc.policy)
+			if tc.rule == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var vErr *validators.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, tc.rule, vErr.Rule)
+		})
+	}
+}
+
+func TestAddressCheckWithPolicy_Messages(t *testing.T) {
+	r := validators.DefaultModuleAccountRegistry()
+	feeCollector := bech32Account(t, validators.ModuleAddress(constants.FeeCollectorName))
+
+	err := validators.AddressCheckWithPolicy("recipient", feeCollector, validators.AddressPolicy{Registry: r})
+	require.ErrorIs(t, err, validators.ErrAddressNotAllowed)
+	require.NotErrorIs(t, err, sdkerrors.ErrUnauthorized)
+	require.Equal(
+		t,
+		"recipient address: '"+feeCollector+"' is the fee_collector module account and cannot be used here: address not allowed",
+		err.Error(),
+	)
+
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.Codespace, vErr.Codespace())
+	require.Equal(t, uint32(2), vErr.ABCICode())
+
+	r.Block(validators.ModuleAddress(constants.FeeCollectorName), "fee collector")
+	err = validators.AddressCheckWithPolicy("recipient", feeCollector, validators.AddressPolicy{Registry: r})
+	require.ErrorIs(t, err, validators.ErrAddressNotAllowed)
+	require.Equal(t, "recipient address: '"+feeCollector+"' is blocked (fee collector): address not allowed", err.Error())
+}
@@ -0,0 +1,299 @@
+package validators
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	errorsmod "cosmossdk.io/errors"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// The validators below compose checks declaratively, e.g.
+//
+//	port := validators.String().Named("port").NonEmpty().Len(2, 128).Identifier()
+//	decimals := validators.Uint32().Named("decimal_difference").Max(18)
+//
+// Each validator checks typed values with Validate, exposes the legacy param signature with Param
+// and lists its rules with Rules, so the documentation of module params can be generated.
+//
+// Rule IDs are the field name followed by the rule, e.g. "port.empty", the same as the hand written checks.
+// Validators are immutable: every method returns a copy, so a base validator can be shared and extended.
+
+// DefaultRuleField is the field name of a validator that was not given one with Named.
+const DefaultRuleField = "value"
+
+// RuleInfo describes one rule of a validator.
+type RuleInfo struct {
+	// ID is the rule ID reported in ValidationError.Rule, e.g. "port.invalid_length".
+	ID string
+	// Description is a short human-readable description, e.g. "length between 2 and 128 characters".
+	Description string
+}
+
+// ParamRule is implemented by the validators of this file.
+type ParamRule interface {
+	// Field returns the name of the validated field.
+	Field() string
+	// Rules returns the rules in the order they are checked.
+	Rules() []RuleInfo
+	// Param returns the validator with the legacy func(interface{}) error param signature.
+	Param() func(interface{}) error
+}
+
+// DescribeParams returns a markdown list of the params and their rules, one line per param.
+func DescribeParams(params ...ParamRule) string {
+	var b strings.Builder
+	for _, p := range params {
+		descriptions := make([]string, 0, len(p.Rules()))
+		for _, r := range p.Rules() {
+			descriptions = append(descriptions, r.Description)
+		}
+		fmt.Fprintf(&b, "- %s: %s\n", p.Field(), strings.Join(descriptions, "; "))
+	}
+	return b.String()
+}
+
+// ruleCheck is a single rule and the function checking it.
+type ruleCheck[T any] struct {
+	suffix      string
+	description string
+	min, max    int
+	check       func(v T) (ok bool, format string, args []interface{})
+}
+
+// ruleSet holds the state shared by the typed validators.
+type ruleSet[T any] struct {
+	field  string
+	code   *errorsmod.Error
+	checks []ruleCheck[T]
+}
+
+func newRuleSet[T any]() ruleSet[T] {
+	return ruleSet[T]{field: DefaultRuleField, code: sdkerrors.ErrInvalidRequest}
+}
+
+// with returns a copy of the rule set with the check appended.
+func (s ruleSet[T]) with(c ruleCheck[T]) ruleSet[T] {
+	checks := make([]ruleCheck[T], len(s.checks), len(s.checks)+1)
+	copy(checks, s.checks)
+	s.checks = append(checks, c)
+	return s
+}
+
+func (s ruleSet[T]) validate(v T) error {
+	for _, c := range s.checks {
+		ok, format, args := c.check(v)
+		if ok {
+			continue
+		}
+		return wrapValidationError(
+			s.code,
+			s.field,
+			s.field+"."+c.suffix,
+			fmt.Sprintf("%v", v),
+			"%s "+format,
+			append([]interface{}{s.field}, args...)...,
+		).withLimits(c.min, c.max)
+	}
+	return nil
+}
+
+func (s ruleSet[T]) rules() []RuleInfo {
+	rules := make([]RuleInfo, len(s.checks))
+	for i, c := range s.checks {
+		rules[i] = RuleInfo{ID: s.field + "." + c.suffix, Description: c.description}
+	}
+	return rules
+}
+
+func (s ruleSet[T]) param() func(interface{}) error {
+	return func(i interface{}) error {
+		v, ok := i.(T)
+		if !ok {
+			return invalidParamTypeError(s.field, i)
+		}
+		return s.validate(v)
+	}
+}
+
+// StringValidator validates string values.
+type StringValidator struct {
+	set ruleSet[string]
+}
+
+// String returns a string validator without rules.
+func String() StringValidator {
+	return StringValidator{set: newRuleSet[string]()}
+}
+
+// Named sets the field name used in error messages and rule IDs.
+func (v StringValidator) Named(field string) StringValidator {
+	v.set.field = field
+	return v
+}
+
+// Code sets the registered error reported by the validator, sdkerrors.ErrInvalidRequest by default.
+func (v StringValidator) Code(code *errorsmod.Error) StringValidator {
+	v.set.code = code
+	return v
+}
+
+// NonEmpty rejects the empty string, rule "<field>.empty".
+func (v StringValidator) NonEmpty() StringValidator {
+	v.set = v.set.with(ruleCheck[string]{
+		suffix:      "empty",
+		description: "not empty",
+		check: func(s string) (bool, string, []interface{}) {
+			return s != "", "cannot be empty", nil
+		},
+	})
+	return v
+}
+
+// Len requires a length in bytes between min and max inclusive, rule "<field>.invalid_length".
+func (v StringValidator) Len(min, max int) StringValidator {
+	v.set = v.set.with(ruleCheck[string]{
+		suffix:      "invalid_length",
+		description: fmt.Sprintf("length between %d and %d characters", min, max),
+		min:         min,
+		max:         max,
+		check: func(s string) (bool, string, []interface{}) {
+			return StringLengthInRange(s, min, max),
+				"length must be between %d and %d characters, got %d",
+				[]interface{}{min, max, len(s)}
+		},
+	})
+	return v
+}
+
+// Identifier requires the characters accepted by IsValidIdentifier, rule "<field>.invalid_chars".
+func (v StringValidator) Identifier() StringValidator {
+	v.set = v.set.with(ruleCheck[string]{
+		suffix:      "invalid_chars",
+		description: "only alphanumeric, ., _, +, -, #, [, ], <, > characters",
+		check: func(s string) (bool, string, []interface{}) {
+			return IsValidIdentifier(s),
+				"contains invalid characters. Only alphanumeric, ., _, +, -, #, [, ], <, > are allowed",
+				nil
+		},
+	})
+	return v
+}
+
+// Check adds a custom rule, rule "<field>.<suffix>". check returns a non-nil error when the value is invalid;
+// its message is used in the ValidationError.
+func (v StringValidator) Check(suffix, description string, check func(string) error) StringValidator {
+	v.set = v.set.with(ruleCheck[string]{
+		suffix:      suffix,
+		description: description,
+		check: func(s string) (bool, string, []interface{}) {
+			if err := check(s); err != nil {
+				return false, "'%s' is invalid (%s)", []interface{}{s, err}
+			}
+			return true, "", nil
+		},
+	})
+	return v
+}
+
+// Field returns the name of the validated field.
+func (v StringValidator) Field() string {
+	return v.set.field
+}
+
+// Rules returns the rules in the order they are checked.
+func (v StringValidator) Rules() []RuleInfo {
+	return v.set.rules()
+}
+
+// Validate checks the value against every rule and returns the first violation as a ValidationError.
+func (v StringValidator) Validate(s string) error {
+	return v.set.validate(s)
+}
+
+// Param returns the validator with the legacy func(interface{}) error param signature.
+func (v StringValidator) Param() func(interface{}) error {
+	return v.set.param()
+}
+
+// UintValidator validates unsigned integer values.
+type UintValidator[T uint32 | uint64] struct {
+	set ruleSet[T]
+}
+
+// Uint32 returns a uint32 validator without rules.
+func Uint32() UintValidator[uint32] {
+	return UintValidator[uint32]{set: newRuleSet[uint32]()}
+}
+
+// Uint64 returns a uint64 validator without rules.
+func Uint64() UintValidator[uint64] {
+	return UintValidator[uint64]{set: newRuleSet[uint64]()}
+}
+
+// Named sets the field name used in error messages and rule IDs.
+func (v UintValidator[T]) Named(field string) UintValidator[T] {
+	v.set.field = field
+	return v
+}
+
+// Code sets the registered error reported by the validator, sdkerrors.ErrInvalidRequest by default.
+func (v UintValidator[T]) Code(code *errorsmod.Error) UintValidator[T] {
+	v.set.code = code
+	return v
+}
+
+// Min requires a value greater than or equal to min, rule "<field>.too_small".
+func (v UintValidator[T]) Min(min T) UintValidator[T] {
+	v.set = v.set.with(ruleCheck[T]{
+		suffix:      "too_small",
+		description: fmt.Sprintf("at least %d", min),
+		min:         limitToInt(min),
+		check: func(n T) (bool, string, []interface{}) {
+			return n >= min, "cannot be less than %d, got %d", []interface{}{min, n}
+		},
+	})
+	return v
+}
+
+// Max requires a value lower than or equal to max, rule "<field>.too_large".
+func (v UintValidator[T]) Max(max T) UintValidator[T] {
+	v.set = v.set.with(ruleCheck[T]{
+		suffix:      "too_large",
+		description: fmt.Sprintf("at most %d", max),
+		max:         limitToInt(max),
+		check: func(n T) (bool, string, []interface{}) {
+			return n <= max, "cannot be greater than %d, got %d", []interface{}{max, n}
+		},
+	})
+	return v
+}
+
+// Field returns the name of the validated field.
+func (v UintValidator[T]) Field() string {
+	return v.set.field
+}
+
+// Rules returns the rules in the order they are checked.
+func (v UintValidator[T]) Rules() []RuleInfo {
+	return v.set.rules()
+}
+
+// Validate checks the value against every rule and returns the first violation as a ValidationError.
+func (v UintValidator[T]) Validate(n T) error {
+	return v.set.validate(n)
+}
+
+// Param returns the validator with the legacy func(interface{}) error param signature.
+func (v UintValidator[T]) Param() func(interface{}) error {
+	return v.set.param()
+}
+
+// limitToInt converts a limit to the int of ValidationError.Min and Max, capped at math.MaxInt.
+func limitToInt[T uint32 | uint64](n T) int {
+	if uint64(n) > math.MaxInt {
+		return math.MaxInt
+	}
+	return int(n)
+}
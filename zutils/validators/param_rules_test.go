@@ -0,0 +1,149 @@
+package validators_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	porttypes "github.com/cosmos/ibc-go/v10/modules/core/05-port/types"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/validators"
+)
+
+func TestStringValidator(t *testing.T) {
+	port := validators.String().Named("port").NonEmpty().Len(2, 128).Identifier()
+
+	testCases := []struct {
+		desc  string
+		value string
+		rule  string
+	}{
+		{desc: "valid", value: "transfer"},
+		{desc: "empty", value: "", rule: validators.RulePortEmpty},
+		{desc: "too short", value: "a", rule: validators.RulePortInvalidLength},
+		{desc: "invalid chars", value: "bad port", rule: validators.RulePortInvalidChars},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := port.Validate(tc.value)
+			if tc.rule == "" {
+				require.NoError(t, err)
+				require.NoError(t, port.Param()(tc.value))
+				return
+			}
+
+			var vErr *validators.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, tc.rule, vErr.Rule)
+			require.Equal(t, "port", vErr.Field)
+			require.Equal(t, tc.value, vErr.Value)
+			require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+			// the param adapter reports the same error
+			require.Equal(t, err.Error(), port.Param()(tc.value).Error())
+		})
+	}
+}
+
+func TestStringValidator_Messages(t *testing.T) {
+	v := validators.String().NonEmpty().Len(2, 4)
+
+	require.Equal(t, "value cannot be empty: invalid request", v.Validate("").Error())
+
+	err := v.Validate("abcde")
+	require.Equal(t, "value length must be between 2 and 4 characters, got 5: invalid request", err.Error())
+
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, "value.invalid_length", vErr.Rule)
+	require.Equal(t, 2, vErr.Min)
+	require.Equal(t, 4, vErr.Max)
+}
+
+func TestStringValidator_CodeAndCheck(t *testing.T) {
+	v := validators.String().
+		Named("port").
+		Code(porttypes.ErrInvalidPort).
+		Check("reserved", "not a reserved port", func(s string) error {
+			if s == "ibc" {
+				return fmt.Errorf("port %s is reserved", s)
+			}
+			return nil
+		})
+
+	require.NoError(t, v.Validate("transfer"))
+
+	err := v.Validate("ibc")
+	require.ErrorIs(t, err, porttypes.ErrInvalidPort)
+	require.Equal(t, "port 'ibc' is invalid (port ibc is reserved): invalid port", err.Error())
+
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, "port.reserved", vErr.Rule)
+}
+
+func TestStringValidator_Immutable(t *testing.T) {
+	base := validators.String().Named("denom").NonEmpty()
+	short := base.Len(1, 3)
+	long := base.Len(1, 10)
+
+	require.Len(t, base.Rules(), 1)
+	require.NoError(t, base.Validate("abcdef"))
+	require.Error(t, short.Validate("abcdef"))
+	require.NoError(t, long.Validate("abcdef"))
+}
+
+func TestUintValidator(t *testing.T) {
+	decimals := validators.Uint32().Named("decimal_difference").Max(18)
+
+	require.NoError(t, decimals.Validate(18))
+	require.NoError(t, decimals.Param()(uint32(0)))
+
+	err := decimals.Validate(19)
+	require.Equal(t, "decimal_difference cannot be greater than 18, got 19: invalid request", err.Error())
+
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleDecimalDifferenceTooLarge, vErr.Rule)
+	require.Equal(t, 18, vErr.Max)
+
+	// the param adapter checks the type
+	err = decimals.Param()(18)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidType)
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, validators.RuleParamInvalidType, vErr.Rule)
+	require.Equal(t, "invalid parameter type: int", err.Error())
+
+	ids := validators.Uint64().Named("next_pool_id").Min(1).Max(math.MaxUint64)
+	require.NoError(t, ids.Validate(math.MaxUint64))
+
+	err = ids.Validate(0)
+	require.Equal(t, "next_pool_id cannot be less than 1, got 0: invalid request", err.Error())
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, "next_pool_id.too_small", vErr.Rule)
+	require.Equal(t, 1, vErr.Min)
+}
+
+func TestDescribeParams(t *testing.T) {
+	params := []validators.ParamRule{
+		validators.String().Named("port").NonEmpty().Len(2, 128).Identifier(),
+		validators.Uint32().Named("decimal_difference").Max(18),
+	}
+
+	require.Equal(
+		t,
+		"- port: not empty; length between 2 and 128 characters; only alphanumeric, ., _, +, -, #, [, ], <, > characters\n"+
+			"- decimal_difference: at most 18\n",
+		validators.DescribeParams(params...),
+	)
+
+	require.Equal(
+		t,
+		[]validators.RuleInfo{{ID: "decimal_difference.too_large", Description: "at most 18"}},
+		params[1].Rules(),
+	)
+}
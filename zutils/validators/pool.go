@@ -0,0 +1,159 @@
+package validators
+
+import (
+	"encoding/json"
+	"strconv"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"zigchain/zutils/constants"
+)
+
+// PoolID is the numeric id of a dex pool, represented as constants.PoolPrefix followed by the number, e.g. zp123.
+//
+// Valid ids are in the range [1, constants.MaxPoolID]; the zero value is not a valid pool id.
+// The string form is canonical: no leading zeros, so each id has exactly one representation.
+//
+// PoolID implements encoding.TextMarshaler, json.Marshaler and the gogoproto customtype
+// interface, and is always encoded as its string form.
+type PoolID uint64
+
+// NewPoolID creates a PoolID from the number stored by the dex keeper and checks its bounds.
+func NewPoolID(id uint64) (PoolID, error) {
+	poolID := PoolID(id)
+	if err := poolID.Validate(); err != nil {
+		return 0, err
+	}
+	return poolID, nil
+}
+
+// ParsePoolID parses a pool id string such as zp123.
+//
+// It performs the following checks:
+// 1. The string passes CheckPoolId.
+// 2. The number has no leading zeros (zp007 is rejected, use zp7).
+// 3. The number is in the range [1, constants.MaxPoolID].
+func ParsePoolID(poolId string) (PoolID, error) {
+	if err := CheckPoolId(poolId); err != nil {
+		return 0, err
+	}
+
+	digits := poolId[len(constants.PoolPrefix):]
+	if len(digits) > 1 && digits[0] == '0' {
+		return 0, wrapValidationError(
+			sdkerrors.ErrInvalidCoins,
+			FieldPoolID,
+			RulePoolIDNonCanonical,
+			poolId,
+			"Invalid pool id: '%s' cannot have leading zeros e.g. %s123",
+			poolId,
+			constants.PoolPrefix,
+		)
+	}
+
+	id, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, poolIDOutOfRangeError(poolId)
+	}
+
+	return NewPoolID(id)
+}
+
+// FormatPoolID returns the canonical string form of a pool id number, e.g. 123 -> zp123.
+// It does not check the bounds, use NewPoolID for that.
+func FormatPoolID(id uint64) string {
+	return constants.PoolPrefix + strconv.FormatUint(id, 10)
+}
+
+// Uint64 returns the pool id number.
+func (p PoolID) Uint64() uint64 {
+	return uint64(p)
+}
+
+// String returns the canonical string form, e.g. zp123.
+func (p PoolID) String() string {
+	return FormatPoolID(uint64(p))
+}
+
+// Validate checks that the pool id is in the range [1, constants.MaxPoolID].
+func (p PoolID) Validate() error {
+	if p == 0 || p > constants.MaxPoolID {
+		return poolIDOutOfRangeError(p.String())
+	}
+	return nil
+}
+
+// poolIDOutOfRangeError is returned for pool ids outside [1, constants.MaxPoolID].
+func poolIDOutOfRangeError(poolId string) error {
+	return wrapValidationError(
+		sdkerrors.ErrInvalidCoins,
+		FieldPoolID,
+		RulePoolIDOutOfRange,
+		poolId,
+		"Invalid pool id: '%s' is out of range, it has to be between %s1 and %s%d",
+		poolId,
+		constants.PoolPrefix,
+		constants.PoolPrefix,
+		uint64(constants.MaxPoolID),
+	).withLimits(1, constants.MaxPoolID)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (p PoolID) MarshalText() ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (p *PoolID) UnmarshalText(text []byte) error {
+	id, err := ParsePoolID(string(text))
+	if err != nil {
+		return err
+	}
+	*p = id
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, the pool id is encoded as a JSON string.
+func (p PoolID) MarshalJSON() ([]byte, error) {
+	text, err := p.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PoolID) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return p.UnmarshalText([]byte(text))
+}
+
+// Marshal implements the gogoproto custom type interface.
+func (p PoolID) Marshal() ([]byte, error) {
+	return p.MarshalText()
+}
+
+// MarshalTo implements the gogoproto custom type interface.
+func (p *PoolID) MarshalTo(data []byte) (n int, err error) {
+	bz, err := p.Marshal()
+	if err != nil {
+		return 0, err
+	}
+	return copy(data, bz), nil
+}
+
+// Unmarshal implements the gogoproto custom type interface.
+func (p *PoolID) Unmarshal(data []byte) error {
+	return p.UnmarshalText(data)
+}
+
+// Size implements the gogoproto custom type interface.
+func (p *PoolID) Size() int {
+	return len(p.String())
+}
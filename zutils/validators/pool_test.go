@@ -0,0 +1,132 @@
+package validators_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"zigchain/zutils/constants"
+	"zigchain/zutils/validators"
+)
+
+func TestParsePoolID_Valid(t *testing.T) {
+	testCases := []struct {
+		poolId   string
+		expected uint64
+	}{
+		{poolId: "zp1", expected: 1},
+		{poolId: "zp123", expected: 123},
+		{poolId: "zp10", expected: 10},
+		{poolId: "zp99999999999", expected: constants.MaxPoolID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.poolId, func(t *testing.T) {
+			id, err := validators.ParsePoolID(tc.poolId)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, id.Uint64())
+			require.Equal(t, tc.poolId, id.String())
+			require.Equal(t, tc.poolId, validators.FormatPoolID(tc.expected))
+		})
+	}
+}
+
+func TestParsePoolID_Invalid(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		poolId string
+		rule   string
+	}{
+		{desc: "empty", poolId: "", rule: validators.RulePoolIDEmpty},
+		{desc: "wrong prefix", poolId: "xp123", rule: validators.RulePoolIDInvalidPrefix},
+		{desc: "letters", poolId: "zp12a", rule: validators.RulePoolIDInvalidFormat},
+		{desc: "leading zeros", poolId: "zp007", rule: validators.RulePoolIDNonCanonical},
+		{desc: "zero padded zero", poolId: "zp00", rule: validators.RulePoolIDNonCanonical},
+		{desc: "zero", poolId: "zp0", rule: validators.RulePoolIDOutOfRange},
+		{desc: "above max", poolId: "zp100000000000", rule: validators.RulePoolIDOutOfRange},
+		{desc: "overflows uint64", poolId: "zp99999999999999999999999", rule: validators.RulePoolIDOutOfRange},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := validators.ParsePoolID(tc.poolId)
+			require.Error(t, err)
+
+			var vErr *validators.ValidationError
+			require.True(t, errors.As(err, &vErr))
+			require.Equal(t, tc.rule, vErr.Rule)
+		})
+	}
+}
+
+func TestNewPoolID(t *testing.T) {
+	id, err := validators.NewPoolID(42)
+	require.NoError(t, err)
+	require.Equal(t, validators.PoolID(42), id)
+
+	_, err = validators.NewPoolID(0)
+	require.Error(t, err)
+
+	_, err = validators.NewPoolID(constants.MaxPoolID + 1)
+	require.Error(t, err)
+	require.Equal(
+		t,
+		"Invalid pool id: 'zp100000000000' is out of range, it has to be between zp1 and zp99999999999: invalid coins",
+		err.Error(),
+	)
+}
+
+func TestPoolID_JSON(t *testing.T) {
+	type wrapper struct {
+		PoolID validators.PoolID `json:"pool_id"`
+	}
+
+	bz, err := json.Marshal(wrapper{PoolID: 123})
+	require.NoError(t, err)
+	require.Equal(t, `{"pool_id":"zp123"}`, string(bz))
+
+	var w wrapper
+	require.NoError(t, json.Unmarshal(bz, &w))
+	require.Equal(t, validators.PoolID(123), w.PoolID)
+
+	require.Error(t, json.Unmarshal([]byte(`{"pool_id":"zp007"}`), &w))
+	require.Error(t, json.Unmarshal([]byte(`{"pool_id":123}`), &w))
+
+	_, err = json.Marshal(wrapper{})
+	require.Error(t, err, "zero value is not a valid pool id")
+}
+
+func TestPoolID_Text(t *testing.T) {
+	id := validators.PoolID(7)
+
+	text, err := id.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "zp7", string(text))
+
+	var parsed validators.PoolID
+	require.NoError(t, parsed.UnmarshalText(text))
+	require.Equal(t, id, parsed)
+}
+
+func TestPoolID_Proto(t *testing.T) {
+	id := validators.PoolID(99)
+
+	bz, err := id.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, []byte("zp99"), bz)
+	require.Equal(t, len(bz), id.Size())
+
+	buf := make([]byte, id.Size())
+	n, err := id.MarshalTo(buf)
+	require.NoError(t, err)
+	require.Equal(t, len(bz), n)
+	require.Equal(t, bz, buf)
+
+	var parsed validators.PoolID
+	require.NoError(t, parsed.Unmarshal(bz))
+	require.Equal(t, id, parsed)
+
+	require.Error(t, parsed.Unmarshal([]byte("zp0")))
+}
@@ -0,0 +1,159 @@
+package validators
+
+import (
+	"fmt"
+
+	sdkmath "cosmossdk.io/math"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// MaxRateLimitPercent is the highest send or recv threshold of a rate limit, in percent of the channel value.
+const MaxRateLimitPercent = 100
+
+// durationHoursRule requires a rate limit window of at least one hour.
+var durationHoursRule = Uint64().Named(FieldDurationHours).Min(1)
+
+// RateLimitPath identifies a rate limit of the IBC ratelimit module: the flow of one denom through one channel.
+type RateLimitPath struct {
+	Denom   string
+	Channel string
+}
+
+// Validate checks the denom with ClassifyDenom, so native, factory, ibc/{hash} and pool share denoms
+// are accepted, and the channel with ValidateChannel.
+// Every violation is reported, as ValidationErrors.
+func (p RateLimitPath) Validate() error {
+	var errs ValidationErrors
+	_, _, err := ClassifyDenom(p.Denom)
+	errs = errs.add(err)
+	errs = errs.add(ValidateChannel(p.Channel))
+	return errs.errOrNil()
+}
+
+// RateLimitQuota holds the thresholds of a rate limit, as in MsgAddRateLimit and MsgUpdateRateLimit.
+//
+// MaxPercentSend and MaxPercentRecv are the share of the channel value, in percent, that can flow out
+// and in during a window of DurationHours; 0 disables the limit of that direction.
+type RateLimitQuota struct {
+	MaxPercentSend sdkmath.Int
+	MaxPercentRecv sdkmath.Int
+	DurationHours  uint64
+}
+
+// Validate checks the quota, see ValidateRateLimitQuota.
+func (q RateLimitQuota) Validate() error {
+	return ValidateRateLimitQuota(q)
+}
+
+// ValidateRateLimitQuota validates a RateLimitQuota or *RateLimitQuota.
+//
+// It performs the following checks:
+// 1. MaxPercentSend and MaxPercentRecv are set and between 0 and MaxRateLimitPercent.
+// 2. MaxPercentSend and MaxPercentRecv are not both 0, such a rate limit does nothing.
+// 3. DurationHours is at least 1.
+//
+// Every violation is reported, as ValidationErrors.
+func ValidateRateLimitQuota(i interface{}) error {
+	var quota RateLimitQuota
+	switch v := i.(type) {
+	case RateLimitQuota:
+		quota = v
+	case *RateLimitQuota:
+		if v == nil {
+			return invalidParamTypeError(FieldRateLimit, i)
+		}
+		quota = *v
+	default:
+		return invalidParamTypeError(FieldRateLimit, i)
+	}
+
+	var errs ValidationErrors
+	sendErr := ValidateRateLimitPercent(FieldMaxPercentSend, quota.MaxPercentSend)
+	recvErr := ValidateRateLimitPercent(FieldMaxPercentRecv, quota.MaxPercentRecv)
+	errs = errs.add(sendErr)
+	errs = errs.add(recvErr)
+
+	if sendErr == nil && recvErr == nil && quota.MaxPercentSend.IsZero() && quota.MaxPercentRecv.IsZero() {
+		errs = errs.add(wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			FieldRateLimit,
+			RuleRateLimitZeroThresholds,
+			"",
+			"rate limit: %s and %s cannot both be 0",
+			FieldMaxPercentSend,
+			FieldMaxPercentRecv,
+		))
+	}
+
+	errs = errs.add(durationHoursRule.Validate(quota.DurationHours))
+
+	return errs.errOrNil()
+}
+
+// ValidateRateLimitPercent checks that a send or recv threshold is set and between 0 and MaxRateLimitPercent,
+// rule "<field>.out_of_range".
+func ValidateRateLimitPercent(field string, percent sdkmath.Int) error {
+	if percent.IsNil() || percent.IsNegative() || percent.GT(sdkmath.NewInt(MaxRateLimitPercent)) {
+		value := "nil"
+		if !percent.IsNil() {
+			value = percent.String()
+		}
+		return wrapValidationError(
+			sdkerrors.ErrInvalidRequest,
+			field,
+			field+".out_of_range",
+			value,
+			"%s has to be between 0 and %d, got %s",
+			field,
+			MaxRateLimitPercent,
+			value,
+		).withLimits(0, MaxRateLimitPercent)
+	}
+	return nil
+}
+
+// ValidateRateLimitDurationHours checks the length of a rate limit window, rule "duration_hours.too_small".
+func ValidateRateLimitDurationHours(i interface{}) error {
+	return durationHoursRule.Param()(i)
+}
+
+// WhitelistedAddressPair is a sender and receiver whose transfers are not counted by the rate limits.
+type WhitelistedAddressPair struct {
+	Sender   string
+	Receiver string
+}
+
+// ValidateRateLimitWhitelist validates the address pairs exempted from the rate limits.
+//
+// It performs the following checks:
+// 1. Both addresses of every pair pass ForeignAddressCheck, either end can be on another chain.
+// 2. No pair is listed twice.
+//
+// Every violation is reported, as ValidationErrors. An empty whitelist is valid.
+func ValidateRateLimitWhitelist(pairs []WhitelistedAddressPair) error {
+	var errs ValidationErrors
+	seen := make(map[WhitelistedAddressPair]int, len(pairs))
+
+	for i, pair := range pairs {
+		errs = errs.add(ForeignAddressCheck(FieldSender, pair.Sender))
+		errs = errs.add(ForeignAddressCheck(FieldReceiver, pair.Receiver))
+
+		if first, ok := seen[pair]; ok {
+			errs = errs.add(wrapValidationError(
+				sdkerrors.ErrInvalidRequest,
+				FieldWhitelist,
+				RuleWhitelistDuplicatePair,
+				fmt.Sprintf("%s,%s", pair.Sender, pair.Receiver),
+				"whitelist: pair %d (%s -> %s) is a duplicate of pair %d",
+				i,
+				pair.Sender,
+				pair.Receiver,
+				first,
+			))
+			continue
+		}
+		seen[pair] = i
+	}
+
+	return errs.errOrNil()
+}
@@ -0,0 +1,158 @@
+package validators_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	sdkmath "cosmossdk.io/math"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"zigchain/testutil/sample"
+	"zigchain/zutils/validators"
+)
+
+func TestRateLimitPath_Validate(t *testing.T) {
+	require.NoError(t, validators.RateLimitPath{Denom: "uzig", Channel: "channel-0"}.Validate())
+	require.NoError(t, validators.RateLimitPath{
+		Denom:   "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
+		Channel: "channel-12",
+	}.Validate())
+	// factory tokens are the denoms this chain rate-limits
+	require.NoError(t, validators.RateLimitPath{Denom: "factory/" + sample.AccAddress() + "/abc", Channel: "channel-0"}.Validate())
+	require.NoError(t, validators.RateLimitPath{Denom: "zp1", Channel: "channel-0"}.Validate())
+
+	err := validators.RateLimitPath{Denom: "factory/invalid/abc", Channel: "channel-0"}.Validate()
+	var errs validators.ValidationErrors
+	require.True(t, errors.As(err, &errs))
+	require.Equal(t, validators.RuleAddressInvalidBech32, errs[0].Rule)
+
+	err = validators.RateLimitPath{Denom: "ibc/nothash", Channel: "chan"}.Validate()
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 2)
+	require.Equal(t, validators.RuleDenomInvalidIBCHash, errs[0].Rule)
+	require.Equal(t, validators.RuleChannelInvalidFormat, errs[1].Rule)
+
+	err = validators.RateLimitPath{Denom: "", Channel: "channel-0"}.Validate()
+	require.True(t, errors.As(err, &errs))
+	require.Equal(t, validators.RuleDenomEmpty, errs[0].Rule)
+}
+
+func TestValidateRateLimitQuota_Valid(t *testing.T) {
+	quota := validators.RateLimitQuota{
+		MaxPercentSend: sdkmath.NewInt(10),
+		MaxPercentRecv: sdkmath.NewInt(100),
+		DurationHours:  24,
+	}
+	require.NoError(t, quota.Validate())
+	require.NoError(t, validators.ValidateRateLimitQuota(&quota))
+
+	// a single direction can be disabled
+	quota.MaxPercentRecv = sdkmath.ZeroInt()
+	require.NoError(t, quota.Validate())
+}
+
+func TestValidateRateLimitQuota_Invalid(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		quota validators.RateLimitQuota
+		rules []string
+	}{
+		{
+			desc:  "send above 100",
+			quota: validators.RateLimitQuota{MaxPercentSend: sdkmath.NewInt(101), MaxPercentRecv: sdkmath.NewInt(5), DurationHours: 1},
+			rules: []string{validators.RuleMaxPercentSendOutOfRange},
+		},
+		{
+			desc:  "negative recv",
+			quota: validators.RateLimitQuota{MaxPercentSend: sdkmath.NewInt(5), MaxPercentRecv: sdkmath.NewInt(-1), DurationHours: 1},
+			rules: []string{validators.RuleMaxPercentRecvOutOfRange},
+		},
+		{
+			desc:  "nil thresholds",
+			quota: validators.RateLimitQuota{DurationHours: 1},
+			rules: []string{validators.RuleMaxPercentSendOutOfRange, validators.RuleMaxPercentRecvOutOfRange},
+		},
+		{
+			desc:  "both thresholds zero",
+			quota: validators.RateLimitQuota{MaxPercentSend: sdkmath.ZeroInt(), MaxPercentRecv: sdkmath.ZeroInt(), DurationHours: 1},
+			rules: []string{validators.RuleRateLimitZeroThresholds},
+		},
+		{
+			desc:  "zero duration",
+			quota: validators.RateLimitQuota{MaxPercentSend: sdkmath.NewInt(5), MaxPercentRecv: sdkmath.NewInt(5)},
+			rules: []string{validators.RuleDurationHoursTooSmall},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tc.quota.Validate()
+			require.ErrorIs(t, err, sdkerrors.ErrInvalidRequest)
+
+			var errs validators.ValidationErrors
+			require.True(t, errors.As(err, &errs))
+			rules := make([]string, len(errs))
+			for i, e := range errs {
+				rules[i] = e.Rule
+			}
+			require.Equal(t, tc.rules, rules)
+		})
+	}
+
+	err := validators.ValidateRateLimitQuota("10")
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidType)
+}
+
+func TestValidateRateLimitPercent(t *testing.T) {
+	require.NoError(t, validators.ValidateRateLimitPercent(validators.FieldMaxPercentSend, sdkmath.ZeroInt()))
+	require.NoError(t, validators.ValidateRateLimitPercent(validators.FieldMaxPercentSend, sdkmath.NewInt(100)))
+
+	err := validators.ValidateRateLimitPercent(validators.FieldMaxPercentSend, sdkmath.NewInt(250))
+	require.EqualError(t, err, "max_percent_send has to be between 0 and 100, got 250: invalid request")
+
+	var vErr *validators.ValidationError
+	require.True(t, errors.As(err, &vErr))
+	require.Equal(t, 0, vErr.Min)
+	require.Equal(t, validators.MaxRateLimitPercent, vErr.Max)
+	require.Equal(t, "250", vErr.Value)
+}
+
+func TestValidateRateLimitDurationHours(t *testing.T) {
+	require.NoError(t, validators.ValidateRateLimitDurationHours(uint64(1)))
+
+	err := validators.ValidateRateLimitDurationHours(uint64(0))
+	require.EqualError(t, err, "duration_hours cannot be less than 1, got 0: invalid request")
+
+	err = validators.ValidateRateLimitDurationHours(24)
+	require.ErrorIs(t, err, sdkerrors.ErrInvalidType)
+}
+
+func TestValidateRateLimitWhitelist(t *testing.T) {
+	local := sample.AccAddress()
+	foreign := mustBech32(t, "osmo", bytes.Repeat([]byte{0x02}, 20))
+
+	require.NoError(t, validators.ValidateRateLimitWhitelist(nil))
+	require.NoError(t, validators.ValidateRateLimitWhitelist([]validators.WhitelistedAddressPair{
+		{Sender: local, Receiver: foreign},
+		{Sender: foreign, Receiver: local},
+	}))
+
+	err := validators.ValidateRateLimitWhitelist([]validators.WhitelistedAddressPair{
+		{Sender: local, Receiver: foreign},
+		{Sender: "", Receiver: foreign},
+		{Sender: local, Receiver: foreign},
+	})
+	var errs validators.ValidationErrors
+	require.True(t, errors.As(err, &errs))
+	require.Len(t, errs, 2)
+
+	require.Equal(t, validators.FieldSender, errs[0].Field)
+	require.Equal(t, validators.RuleAddressEmpty, errs[0].Rule)
+
+	require.Equal(t, validators.FieldWhitelist, errs[1].Field)
+	require.Equal(t, validators.RuleWhitelistDuplicatePair, errs[1].Rule)
+	require.Contains(t, errs[1].Error(), "pair 2")
+	require.Contains(t, errs[1].Error(), "duplicate of pair 0")
+}
@@ -3,7 +3,6 @@ package validators
 import (
 	"crypto/sha256"
 	"fmt"
-	"regexp"
 
 	"github.com/asaskevich/govalidator"
 )
@@ -19,6 +18,7 @@ func StringLengthInRange(str string, min int, max int) bool {
 }
 
 // SHA256HashOfURL SHA-256 hash of a given URL
+// It hashes the URL string itself, the uri_hash of a metadata document is computed with HashDocument.
 func SHA256HashOfURL(uri string) string {
 	// will assume the URL is valid, so error messages are specific to the hash check
 	// if !IsURI(uri) {
@@ -30,8 +30,7 @@ func SHA256HashOfURL(uri string) string {
 }
 
 // IsSHA256Hash checks if a given string is a valid SHA-256 hash
+// A valid SHA-256 hash is 64 hexadecimal characters (256 bits = 64 hex chars) in any case, see DigestValidator.
 func IsSHA256Hash(input string) bool {
-	// A valid SHA-256 hash is 64 hexadecimal characters (256 bits = 64 hex chars)
-	match, _ := regexp.MatchString(`^[a-fA-F0-9]{64}$`, input)
-	return match
+	return sha256Hex.IsValid(input)
 }